@@ -56,7 +56,7 @@ func TestFoldLeftMatrixSum(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -110,7 +110,7 @@ func TestFoldRightForStrings(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 
 }
@@ -146,7 +146,7 @@ func TestFoldRightForInts(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 
 }
@@ -190,7 +190,7 @@ func TestFlatMap(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -228,7 +228,7 @@ func TestConcatArrayOfArrays(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[][]int](t, result)
 
 }
@@ -272,7 +272,7 @@ func TestMapForStrings(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 
 }
@@ -0,0 +1,34 @@
+package either
+
+import (
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Generates A Left from the left Gen or A Right from the right Gen with equal probability.
+// The Gen shrinks A Left with the Shrinker of left and A Right with the Shrinker of right.
+func EitherOf[A, B any](left propcheck.Gen[A], right propcheck.Gen[B]) propcheck.Gen[Either[A, B]] {
+	g := propcheck.FlatMap(propcheck.Boolean(), func(isLeft bool) propcheck.Gen[Either[A, B]] {
+		if isLeft {
+			return propcheck.Map(left, func(a A) Either[A, B] { return Left[A]{a} })
+		}
+		return propcheck.Map(right, func(b B) Either[A, B] { return Right[B]{b} })
+	})
+	return g.WithShrinker(func(e Either[A, B]) []Either[A, B] {
+		var r []Either[A, B]
+		switch v := e.(type) {
+		case Left[A]:
+			if s := left.Shrinker(); s != nil {
+				for _, a := range s(v.Value) {
+					r = append(r, Left[A]{a})
+				}
+			}
+		case Right[B]:
+			if s := right.Shrinker(); s != nil {
+				for _, b := range s(v.Value) {
+					r = append(r, Right[B]{b})
+				}
+			}
+		}
+		return r
+	})
+}
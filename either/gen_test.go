@@ -0,0 +1,44 @@
+package either
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+	"time"
+)
+
+func TestEitherOfGeneratesBothSides(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	var lefts, rights int
+	for _, e := range EitherOf(propcheck.String(5), propcheck.ChooseInt(0, 10)).Sample(rng, 200) {
+		switch e.(type) {
+		case Left[string]:
+			lefts++
+		case Right[int]:
+			rights++
+		default:
+			t.Errorf("%v was neither a Left nor a Right", e)
+		}
+	}
+	if lefts < 50 || rights < 50 {
+		t.Errorf("Lefts and Rights should have been about equally likely but there were %v Lefts and %v Rights", lefts, rights)
+	}
+}
+
+func TestEitherOfShrinksEachSide(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := propcheck.ForAll(EitherOf(propcheck.ChooseInt(0, 100), propcheck.ChooseInt(0, 100)), "Rights are under 50",
+		func(e Either[int, int]) Either[int, int] { return e },
+		func(e Either[int, int]) (bool, error) {
+			if r, ok := e.(Right[int]); ok && r.Value >= 50 {
+				return false, fmt.Errorf("%v was too big", r.Value)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[Either[int, int]](t, result)
+	if f := result.(propcheck.Falsified[Either[int, int]]); f.FailedCase != (Right[int]{50}) {
+		t.Errorf("The counterexample should have shrunk to Right 50 but was %v", f.FailedCase)
+	}
+}
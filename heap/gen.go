@@ -0,0 +1,32 @@
+package heap
+
+import (
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Generates A heap of between zero and Size elements using the given Gen, the key extractor of New and the lt function of HeapInsert.
+// Elements whose key is already in the heap are dropped because heap keys must be unique.
+// The Gen shrinks by removing elements and by shrinking elements with the Shrinker of kind.
+func HeapOf[A any, B comparable](kind propcheck.Gen[A], bExtractor func(*A) B, lt func(l, r *A) bool) propcheck.Gen[Heap[A, B]] {
+	fromArray := func(xs []A) Heap[A, B] {
+		h := New[A, B](bExtractor)
+		for i := range xs {
+			a := xs[i]
+			if _, ok := h.position[bExtractor(&a)]; !ok {
+				h = HeapInsert(h, &a, lt)
+			}
+		}
+		return h
+	}
+	xs := propcheck.SizedArray(kind)
+	return propcheck.Map(xs, fromArray).WithShrinker(propcheck.ShrinkConvert(xs.Shrinker(), fromArray, toArray[A, B]))
+}
+
+// The elements of the heap in the order of its underlying array.
+func toArray[A any, B comparable](h Heap[A, B]) []A {
+	r := make([]A, 0, len(h.hp))
+	for _, a := range h.hp {
+		r = append(r, *a)
+	}
+	return r
+}
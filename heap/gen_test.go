@@ -0,0 +1,40 @@
+package heap
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+	"time"
+)
+
+func TestHeapOf(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := propcheck.Map(propcheck.ChooseInt(0, 50), func(x int) Cache { return Cache{x, fmt.Sprintf("key:%v", x)} })
+	prop := propcheck.ForAll(HeapOf(ge, elementBExtractor, lt), "HeapOf generates valid heaps",
+		func(h Heap[Cache, string]) Heap[Cache, string] { return h },
+		validateIsAHeap,
+		validateHeapMin,
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectSuccess[Heap[Cache, string]](t, result)
+}
+
+func TestHeapOfShrinks(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := propcheck.Map(propcheck.ChooseInt(0, 1000), func(x int) Cache { return Cache{x, fmt.Sprintf("key:%v", x)} })
+	prop := propcheck.ForAll(HeapOf(ge, elementBExtractor, lt), "Heaps have fewer than 3 elements",
+		func(h Heap[Cache, string]) Heap[Cache, string] { return h },
+		func(h Heap[Cache, string]) (bool, error) {
+			if len(h.hp) >= 3 {
+				return false, fmt.Errorf("the heap had %v elements", len(h.hp))
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[Heap[Cache, string]](t, result)
+	f := result.(propcheck.Falsified[Heap[Cache, string]])
+	if len(f.FailedCase.hp) != 3 {
+		t.Errorf("The counterexample should have shrunk to 3 elements but had %v", len(f.FailedCase.hp))
+	}
+}
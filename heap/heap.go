@@ -214,7 +214,7 @@ func ChangeKey[A, B comparable](h Heap[A, B], currentA, newA *A, lt func(l, r *A
 	l := h.position[b]
 	h.hp[l] = newA
 	parent := ParentIdx(l)
-	if parent > 0 && lt(h.hp[l], h.hp[parent]) {
+	if parent >= 0 && lt(h.hp[l], h.hp[parent]) {
 		return heapifyUp(h, l, lt)
 	} else {
 		return heapifyDown(h, l, lt)
@@ -258,7 +258,7 @@ func HeapDelete[A any, B comparable](h Heap[A, B], i int, lt func(l, r *A) bool)
 	}
 
 	parent := ParentIdx(i)
-	if parent > 0 && lt(h.hp[i], h.hp[parent]) {
+	if parent >= 0 && lt(h.hp[i], h.hp[parent]) {
 		return heapifyUp(h, i, lt), nil
 	} else {
 		return heapifyDown(h, i, lt), nil
@@ -139,7 +139,7 @@ func TestHeapInsertWithEmptyHeap(t *testing.T) {
 		insert,
 		validateIsAHeap, validateHeapMin,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -153,7 +153,7 @@ func TestHeapInsertWithNonEmptyHeap(t *testing.T) {
 		insert,
 		validateIsAHeap, validateHeapMin,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -177,7 +177,7 @@ func TestHeapDeleteEveryElementStartingFromLast(t *testing.T) {
 		delete6ElementsFromHeapOf6,
 		validateIsAHeap, validateHeapMin,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -226,7 +226,7 @@ func TestHeapDeleteMinElement(t *testing.T) {
 		deleteAllFromHeap,
 		validateIsAHeap, heapWrong,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -278,7 +278,7 @@ func TestFindHeapPosition(t *testing.T) {
 		insert,
 		validateIsAHeap, validateHeapPos,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -304,7 +304,7 @@ func TestFindHeapPositionKeyDoesNotExist(t *testing.T) {
 		insert,
 		validateIsAHeap, validateHeapPosDoesNotExist,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -368,7 +368,7 @@ func TestChangeKeyHeapifyDown(t *testing.T) {
 		insertThenChangeKey,
 		validateIsAHeap, validateHeapPos,
 	)
-	result := prop.Run(propcheck.RunParms{500, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 500, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -415,10 +415,34 @@ func TestChangeKeyHeapifyUp(t *testing.T) {
 		insertThenChangeKey,
 		validateIsAHeap, validateHeapPos,
 	)
-	result := prop.Run(propcheck.RunParms{500, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 500, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
+// The children of the root have parent index 0, so a key smaller than the root's must still move up to the root.
+func TestChangeKeyAndDeleteChildrenOfRoot(t *testing.T) {
+	for _, i := range []int{1, 2} {
+		h := insertIntoHeap([]int{10, 20, 30, 40, 50})
+		c := h.hp[i]
+		h = ChangeKey(h, c, &Cache{key: 5, value: c.value}, lt)
+		if ok, err := validateIsAHeap(h); !ok {
+			t.Errorf("Changing the key of index %v to less than the root should have kept a heap but got %v", i, err)
+		}
+		if m, _ := FindMin(h); m.value != c.value {
+			t.Errorf("Expected %v to be the new minimum but was %v", c.value, m.value)
+		}
+
+		h = insertIntoHeap([]int{10, 20, 30, 40, 50})
+		h, err := HeapDelete(h, i, lt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := validateIsAHeap(h); !ok || len(h.hp) != 4 {
+			t.Errorf("Deleting index %v should have left a heap of 4 elements but got %v", i, err)
+		}
+	}
+}
+
 func TestChangeKeyFirstMidAndLast(t *testing.T) {
 	insertThenChangeKey := func(p []int) Heap[Cache, string] {
 		xss := insertIntoHeap(p)
@@ -482,6 +506,145 @@ func TestChangeKeyFirstMidAndLast(t *testing.T) {
 		insertThenChangeKey,
 		validateIsAHeap, validateHeapPos,
 	)
-	result := prop.Run(propcheck.RunParms{500, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 500, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
+
+// The model of a heap for stateful testing: its elements sorted by key. Keys and values are unique.
+type heapModel []Cache
+
+func (m heapModel) has(c Cache) bool {
+	for _, x := range m {
+		if x.key == c.key || x.value == c.value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m heapModel) with(c Cache) heapModel {
+	r := append(heapModel{}, m...)
+	r = append(r, c)
+	sorting.QuickSort(r, func(l, r Cache) bool { return l.key < r.key })
+	return r
+}
+
+func (m heapModel) without(value string) heapModel {
+	var r heapModel
+	for _, x := range m {
+		if x.value != value {
+			r = append(r, x)
+		}
+	}
+	return r
+}
+
+type heapInsert struct{ c Cache }
+
+func (w heapInsert) PreCondition(m heapModel) bool { return !m.has(w.c) }
+func (w heapInsert) Run(h *Heap[Cache, string]) any {
+	c := w.c
+	*h = HeapInsert(*h, &c, lt)
+	return nil
+}
+func (w heapInsert) PostCondition(heapModel, any) error { return nil }
+func (w heapInsert) NextState(m heapModel) heapModel    { return m.with(w.c) }
+func (w heapInsert) String() string                     { return fmt.Sprintf("Insert%v", w.c) }
+
+type heapDeleteMin struct{}
+
+func (w heapDeleteMin) PreCondition(m heapModel) bool { return len(m) > 0 }
+func (w heapDeleteMin) Run(h *Heap[Cache, string]) any {
+	min, err := FindMin(*h)
+	if err != nil {
+		return err
+	}
+	*h, err = HeapDelete(*h, 0, lt)
+	if err != nil {
+		return err
+	}
+	return *min
+}
+func (w heapDeleteMin) PostCondition(m heapModel, result any) error {
+	if result != m[0] {
+		return fmt.Errorf("expected %v", m[0])
+	}
+	return nil
+}
+func (w heapDeleteMin) NextState(m heapModel) heapModel { return m[1:] }
+func (w heapDeleteMin) String() string                  { return "DeleteMin" }
+
+type heapChangeKey struct{ c Cache }
+
+func (w heapChangeKey) PreCondition(m heapModel) bool {
+	return m.has(Cache{value: w.c.value}) && !m.has(Cache{key: w.c.key})
+}
+func (w heapChangeKey) Run(h *Heap[Cache, string]) any {
+	c := w.c
+	*h = ChangeKey(*h, &Cache{value: c.value}, &c, lt)
+	return nil
+}
+func (w heapChangeKey) PostCondition(heapModel, any) error { return nil }
+func (w heapChangeKey) NextState(m heapModel) heapModel {
+	return m.without(w.c.value).with(w.c)
+}
+func (w heapChangeKey) String() string { return fmt.Sprintf("ChangeKey%v", w.c) }
+
+// Checks the heap property and the minimum after every other command.
+type heapCheck struct{}
+
+func (w heapCheck) PreCondition(heapModel) bool { return true }
+func (w heapCheck) Run(h *Heap[Cache, string]) any {
+	if _, err := validateIsAHeap(*h); err != nil {
+		return err
+	}
+	min, err := FindMin(*h)
+	if err != nil {
+		return nil
+	}
+	return *min
+}
+func (w heapCheck) PostCondition(m heapModel, result any) error {
+	if _, ok := result.(error); ok {
+		return fmt.Errorf("the heap property did not hold")
+	}
+	if len(m) == 0 && result != nil || len(m) > 0 && result != m[0] {
+		return fmt.Errorf("expected the minimum to be %v", m)
+	}
+	return nil
+}
+func (w heapCheck) NextState(m heapModel) heapModel { return m }
+func (w heapCheck) String() string                  { return "Check" }
+
+func heapCommands(m heapModel) propcheck.Gen[propcheck.Command[*Heap[Cache, string], heapModel]] {
+	type command = propcheck.Command[*Heap[Cache, string], heapModel]
+	cache := func(key int) Cache { return Cache{key, fmt.Sprintf("key:%v", key)} }
+	return propcheck.FlatMap(propcheck.ChooseInt(0, 4), func(i int) propcheck.Gen[command] {
+		switch {
+		case i == 0:
+			return propcheck.Map(propcheck.ChooseInt(0, 100), func(k int) command { return heapInsert{cache(k)} })
+		case i == 1 && len(m) > 0:
+			return propcheck.Map(propcheck.Product(propcheck.ChooseInt(0, len(m)), propcheck.ChooseInt(0, 100)), func(p propcheck.Pair[int, int]) command {
+				return heapChangeKey{Cache{p.B, m[p.A].value}}
+			})
+		case i == 2:
+			return propcheck.Id[command](heapDeleteMin{})
+		default:
+			return propcheck.Id[command](heapCheck{})
+		}
+	})
+}
+
+func TestHeapAgainstSortedModel(t *testing.T) {
+	sm := propcheck.StateMachine[*Heap[Cache, string], heapModel]{
+		InitialModel: func() heapModel { return nil },
+		NewSystem: func() *Heap[Cache, string] {
+			h := New[Cache, string](elementBExtractor)
+			return &h
+		},
+		Commands: heapCommands,
+	}
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	result := sm.Prop("Heap insert, delete and change key agree with a sorted model").Run(propcheck.RunParms{TestCases: 500, Rng: rng})
+	propcheck.ExpectSuccess[propcheck.CommandSequence[*Heap[Cache, string], heapModel]](t, result)
+}
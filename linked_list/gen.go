@@ -0,0 +1,12 @@
+package linked_list
+
+import (
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Generates A linked list of between zero and Size elements using the given Gen. An empty list is nil.
+// The Gen shrinks by removing elements and by shrinking elements with the Shrinker of kind.
+func LinkedListOf[T any](kind propcheck.Gen[T]) propcheck.Gen[*LinkedList[T]] {
+	xs := propcheck.SizedArray(kind)
+	return propcheck.Map(xs, ToList[T]).WithShrinker(propcheck.ShrinkConvert(xs.Shrinker(), ToList[T], ToArray[T]))
+}
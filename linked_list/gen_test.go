@@ -0,0 +1,27 @@
+package linked_list
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+	"time"
+)
+
+func TestLinkedListOfShrinks(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := propcheck.ForAll(LinkedListOf(propcheck.ChooseInt(0, 100)), "Lists are shorter than 4",
+		func(l *LinkedList[int]) *LinkedList[int] { return l },
+		func(l *LinkedList[int]) (bool, error) {
+			if Len(l) >= 4 {
+				return false, fmt.Errorf("the list had %v elements", Len(l))
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[*LinkedList[int]](t, result)
+	f := result.(propcheck.Falsified[*LinkedList[int]])
+	if xs := ToArray(f.FailedCase); fmt.Sprint(xs) != "[0 0 0 0]" {
+		t.Errorf("The counterexample should have shrunk to [0 0 0 0] but was %v", xs)
+	}
+}
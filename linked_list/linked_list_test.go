@@ -54,7 +54,7 @@ func TestFilter(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -92,7 +92,7 @@ func TestPush(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -133,7 +133,7 @@ func TestAddLast(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -187,7 +187,7 @@ func TestAddWhile(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -214,7 +214,7 @@ func TestTail(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -262,7 +262,7 @@ func TestDropAndLen(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -308,7 +308,7 @@ func TestTailUnwind(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -346,7 +346,7 @@ func TestHead(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -396,7 +396,7 @@ func TestFoldRight(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -458,7 +458,7 @@ func TestFoldLeftAndFoldRight(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -513,7 +513,7 @@ func TestOrderingOfFoldLeftAndFoldRight(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -556,7 +556,7 @@ func TestDeleteInBigOh1(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 
 }
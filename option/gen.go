@@ -0,0 +1,36 @@
+package option
+
+import (
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Generates None one time in noneOdds and otherwise Some value from the given Gen. A noneOdds of zero or less never generates None.
+// The Gen shrinks A Some to None and then by shrinking its value with the Shrinker of kind.
+func OptionOf[A any](kind propcheck.Gen[A], noneOdds int) propcheck.Gen[Option[A]] {
+	some := propcheck.Map(kind, func(a A) Option[A] { return Some[A]{a} })
+	g := some
+	if noneOdds > 0 {
+		g = propcheck.FlatMap(propcheck.ChooseInt(0, noneOdds), func(i int) propcheck.Gen[Option[A]] {
+			if i == 0 {
+				return propcheck.Id[Option[A]](None[A]{})
+			}
+			return some
+		})
+	}
+	return g.WithShrinker(func(o Option[A]) []Option[A] {
+		v, ok := o.(Some[A])
+		if !ok {
+			return nil
+		}
+		var r []Option[A]
+		if noneOdds > 0 {
+			r = append(r, None[A]{})
+		}
+		if s := kind.Shrinker(); s != nil {
+			for _, a := range s(v.Value) {
+				r = append(r, Some[A]{a})
+			}
+		}
+		return r
+	})
+}
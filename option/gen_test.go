@@ -0,0 +1,55 @@
+package option
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+	"time"
+)
+
+func TestOptionOfNoneFrequency(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	nones := 0
+	for _, o := range OptionOf(propcheck.ChooseInt(0, 10), 4).Sample(rng, 1000) {
+		if _, ok := o.(None[int]); ok {
+			nones++
+		}
+	}
+	if nones < 150 || nones > 350 {
+		t.Errorf("About 1 in 4 options should have been None but %v of 1000 were", nones)
+	}
+	for _, o := range OptionOf(propcheck.ChooseInt(0, 10), 0).Sample(rng, 100) {
+		if _, ok := o.(Some[int]); !ok {
+			t.Errorf("A noneOdds of 0 should never have generated None but generated %v", o)
+		}
+	}
+}
+
+func TestOptionOfShrinksToNone(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := propcheck.ForAll(OptionOf(propcheck.ChooseInt(0, 100), 3), "Options are always Some",
+		func(o Option[int]) Option[int] { return o },
+		func(o Option[int]) (bool, error) {
+			if GetOrElse(o, 1000) == 1000 {
+				return false, fmt.Errorf("%v was None", o)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[Option[int]](t, result)
+	prop = propcheck.ForAll(OptionOf(propcheck.ChooseInt(0, 100), 3), "Options are None or under 50",
+		func(o Option[int]) Option[int] { return o },
+		func(o Option[int]) (bool, error) {
+			if x := GetOrElse(o, 0); x >= 50 {
+				return false, fmt.Errorf("%v was too big", x)
+			}
+			return true, nil
+		},
+	)
+	result = prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[Option[int]](t, result)
+	if f := result.(propcheck.Falsified[Option[int]]); f.FailedCase != (Some[int]{50}) {
+		t.Errorf("The counterexample should have shrunk to Some 50 but was %v", f.FailedCase)
+	}
+}
@@ -0,0 +1,597 @@
+package propcheck
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The struct tag key read by Arbitrary, e.g. `propcheck:"min=0,max=10"` or `propcheck:"-"`.
+const TagKey = "propcheck"
+
+// The characters Arbitrary makes strings from, the same character set as String.
+const alphanumerics = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// A Gen and Shrinker of reflect.Values of one type.
+type arbitrary struct {
+	run    func(RNG, Size) (reflect.Value, RNG)
+	shrink func(reflect.Value) []reflect.Value
+}
+
+var registry = struct {
+	sync.RWMutex
+	gens map[reflect.Type]arbitrary
+}{gens: map[reflect.Type]arbitrary{}}
+
+// Makes Arbitrary use g, and its Shrinker, for every value of type T, wherever T appears: as the type itself, A struct field,
+// A slice element and so on. Registering is the way to generate interfaces, channels and functions, to respect the invariants
+// of A domain type, or to replace the derived Gen of A type. Struct tags are ignored for registered types.
+func Register[T any](g Gen[T]) {
+	a := arbitrary{run: func(rng RNG, size Size) (reflect.Value, RNG) {
+		v, r := g.run(rng, size)
+		return valueOf(v), r
+	}}
+	if g.shrink != nil {
+		a.shrink = func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			for _, c := range g.shrink(valueAs[T](v)) {
+				r = append(r, valueOf(c))
+			}
+			return r
+		}
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.gens[typeOf[T]()] = a
+}
+
+/*
+*
+Arbitrary derives A Gen of T by reflection. It generates
+
+  - bools and every numeric kind, across the whole range of the kind unless the tag gives A min and max
+  - strings of alphanumeric characters, slices and maps of between zero and Size elements
+  - arrays, and pointers which are nil about one time in five
+  - structs, by generating each exported field. Unexported fields are left as their zero value.
+
+and shrinks its values field by field and element by element. A type registered with Register uses the registered Gen instead.
+
+Struct fields may be tagged to restrict their values:
+
+	Age   int      `propcheck:"min=0,max=130"` //A number in [0, 130]
+	Tags  []string `propcheck:"min=1,max=3"`   //Between one and three elements. For strings, slices and maps min and max bound the length.
+	Cache *Cache   `propcheck:"-"`             //Always the zero value
+
+Arbitrary panics if T, or the type of A field that is not skipped, cannot be generated, e.g. an interface that is not registered,
+or if A tag cannot be parsed. It panics when it is called, not when the Gen runs.
+*/
+func Arbitrary[T any]() Gen[T] {
+	a := derive(typeOf[T](), tag{}, map[reflect.Type]*arbitrary{})
+	g := genWithSize(func(rng RNG, size Size) (T, RNG) {
+		v, r := a.run(rng, size)
+		return valueAs[T](v), r
+	})
+	return g.WithShrinker(func(t T) []T {
+		var r []T
+		for _, c := range a.shrink(valueOf(t)) {
+			r = append(r, valueAs[T](c))
+		}
+		return r
+	})
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// The reflect.Value of t with type T, even when T is an interface.
+func valueOf[T any](t T) reflect.Value {
+	return reflect.ValueOf(&t).Elem()
+}
+
+func valueAs[T any](v reflect.Value) T {
+	var t T
+	reflect.ValueOf(&t).Elem().Set(v)
+	return t
+}
+
+// A parsed struct tag.
+type tag struct {
+	min, max string
+	skip     bool
+}
+
+func parseTag(s string) tag {
+	var r tag
+	if s == "-" {
+		r.skip = true
+		return r
+	}
+	for _, part := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		switch {
+		case ok && k == "min":
+			r.min = v
+		case ok && k == "max":
+			r.max = v
+		case part != "":
+			panic(fmt.Sprintf("The %v tag %q was not valid, expected min=<n>, max=<n> or -", TagKey, s))
+		}
+	}
+	return r
+}
+
+// The Gen and Shrinker for type t restricted by tag. inProgress holds the structs whose derivation has started, so that
+// recursive types refer to themselves rather than being derived forever.
+func derive(t reflect.Type, tg tag, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	registry.RLock()
+	a, ok := registry.gens[t]
+	registry.RUnlock()
+	if ok {
+		if a.shrink == nil {
+			a.shrink = func(reflect.Value) []reflect.Value { return nil }
+		}
+		return a
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return arbitrary{
+			run: func(rng RNG, _ Size) (reflect.Value, RNG) {
+				i, r := rng.Intn(2)
+				return reflect.ValueOf(i == 0).Convert(t), r
+			},
+			shrink: func(v reflect.Value) []reflect.Value {
+				if v.Bool() {
+					return []reflect.Value{reflect.Zero(t)}
+				}
+				return nil
+			},
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := t.Bits()
+		lo, hi := intBound(tg.min, t, -1<<(bits-1)), intBound(tg.max, t, 1<<(bits-1)-1)
+		return deriveInt(t, lo, hi)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi := uintBound(tg.min, t, 0), uintBound(tg.max, t, math.MaxUint64>>(64-t.Bits()))
+		return deriveUint(t, lo, hi)
+	case reflect.Float32, reflect.Float64:
+		return deriveFloat(t, tg)
+	case reflect.Complex64, reflect.Complex128:
+		part := deriveFloat(reflect.TypeOf(float64(0)), tg)
+		return arbitrary{
+			run: func(rng RNG, size Size) (reflect.Value, RNG) {
+				re, r1 := part.run(rng, size)
+				im, r2 := part.run(r1, size)
+				return reflect.ValueOf(complex(re.Float(), im.Float())).Convert(t), r2
+			},
+			shrink: func(v reflect.Value) []reflect.Value {
+				var r []reflect.Value
+				c := v.Complex()
+				for _, re := range part.shrink(reflect.ValueOf(real(c))) {
+					r = append(r, reflect.ValueOf(complex(re.Float(), imag(c))).Convert(t))
+				}
+				for _, im := range part.shrink(reflect.ValueOf(imag(c))) {
+					r = append(r, reflect.ValueOf(complex(real(c), im.Float())).Convert(t))
+				}
+				return r
+			},
+		}
+	case reflect.String:
+		return deriveString(t, tg)
+	case reflect.Slice:
+		return deriveSlice(t, tg, inProgress)
+	case reflect.Array:
+		return deriveArray(t, inProgress)
+	case reflect.Map:
+		return deriveMap(t, tg, inProgress)
+	case reflect.Pointer:
+		return derivePointer(t, inProgress)
+	case reflect.Struct:
+		return deriveStruct(t, inProgress)
+	default:
+		panic(fmt.Sprintf("Arbitrary cannot generate values of type %v. Use Register to give it a Gen.", t))
+	}
+}
+
+// Parses A tag bound for an integer kind, or returns def if there is none.
+func intBound(s string, t reflect.Type, def int64) int64 {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(s, 10, t.Bits())
+	if err != nil {
+		panic(fmt.Sprintf("The %v tag bound %q was not a valid %v: %v", TagKey, s, t, err))
+	}
+	return n
+}
+
+func uintBound(s string, t reflect.Type, def uint64) uint64 {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.ParseUint(s, 10, t.Bits())
+	if err != nil {
+		panic(fmt.Sprintf("The %v tag bound %q was not a valid %v: %v", TagKey, s, t, err))
+	}
+	return n
+}
+
+// Parses the tag bounds on the length of A string, slice or map, which default to between zero and Size.
+func lengthBounds(tg tag, t reflect.Type) (lo, hi int, sized bool) {
+	lo = int(intBound(tg.min, reflect.TypeOf(0), 0))
+	hi = int(intBound(tg.max, reflect.TypeOf(0), -1))
+	if lo < 0 || tg.max != "" && hi < lo {
+		panic(fmt.Sprintf("The %v tag bounds min=%v,max=%v were not valid for the length of %v", TagKey, tg.min, tg.max, t))
+	}
+	return lo, hi, tg.max == ""
+}
+
+// A random length in [lo, hi], where hi is the larger of lo and Size if the length is sized.
+func chooseLength(rng RNG, size Size, lo, hi int, sized bool) (int, RNG) {
+	if sized {
+		hi = max(lo, size)
+	}
+	n, r := rng.Intn(hi - lo + 1)
+	return lo + n, r
+}
+
+func deriveInt(t reflect.Type, lo, hi int64) arbitrary {
+	if lo > hi {
+		panic(fmt.Sprintf("The %v tag bounds [%v, %v] were empty for %v", TagKey, lo, hi, t))
+	}
+	target := lo
+	if lo < 0 && hi >= 0 {
+		target = 0
+	} else if hi < 0 {
+		target = hi
+	}
+	return arbitrary{
+		run: func(rng RNG, _ Size) (reflect.Value, RNG) {
+			u, r := rng.Uint64()
+			if span := uint64(hi - lo); span != math.MaxUint64 {
+				u %= span + 1
+			}
+			return reflect.ValueOf(lo + int64(u)).Convert(t), r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			for _, c := range ShrinkInt(int(v.Int() - target)) {
+				if x := int64(c) + target; x >= lo && x <= hi {
+					r = append(r, reflect.ValueOf(x).Convert(t))
+				}
+			}
+			return r
+		},
+	}
+}
+
+func deriveUint(t reflect.Type, lo, hi uint64) arbitrary {
+	if lo > hi {
+		panic(fmt.Sprintf("The %v tag bounds [%v, %v] were empty for %v", TagKey, lo, hi, t))
+	}
+	return arbitrary{
+		run: func(rng RNG, _ Size) (reflect.Value, RNG) {
+			u, r := rng.Uint64()
+			if span := hi - lo; span != math.MaxUint64 {
+				u %= span + 1
+			}
+			return reflect.ValueOf(lo + u).Convert(t), r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			x := v.Uint()
+			var r []reflect.Value
+			for _, c := range []uint64{lo, lo + (x-lo)/2, x - 1} {
+				if c >= lo && c < x && (len(r) == 0 || r[len(r)-1].Uint() != c) {
+					r = append(r, reflect.ValueOf(c).Convert(t))
+				}
+			}
+			return r
+		},
+	}
+}
+
+// Floats are uniform in [-Size, Size] limited to [min, max], with A fractional part. When that is empty they are uniform in [min, max]
+// if the tag gives both bounds, or within max(Size, 1) of the one bound it gives, so that the range is always finite.
+func deriveFloat(t reflect.Type, tg tag) arbitrary {
+	parse := func(s string, def float64) float64 {
+		if s == "" {
+			return def
+		}
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			panic(fmt.Sprintf("The %v tag bound %q was not a valid %v: %v", TagKey, s, t, err))
+		}
+		return f
+	}
+	lo, hi := parse(tg.min, math.Inf(-1)), parse(tg.max, math.Inf(1))
+	if lo > hi {
+		panic(fmt.Sprintf("The %v tag bounds [%v, %v] were empty for %v", TagKey, lo, hi, t))
+	}
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			u, r := rng.Uint64()
+			unit := float64(u>>11) / (1 << 53)
+			l, h := math.Max(lo, -float64(size)), math.Min(hi, float64(size))
+			if width := math.Max(float64(size), 1); l > h {
+				switch {
+				case tg.min != "" && tg.max != "":
+					l, h = lo, hi
+				case tg.min != "":
+					l, h = lo, math.Min(hi, lo+width)
+				default:
+					l, h = math.Max(lo, hi-width), hi
+				}
+			}
+			return reflect.ValueOf(l + unit*(h-l)).Convert(t), r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			x := v.Float()
+			var r []reflect.Value
+			for _, c := range []float64{0, math.Trunc(x), x / 2} {
+				if c >= lo && c <= hi && math.Abs(c) < math.Abs(x) {
+					r = append(r, reflect.ValueOf(c).Convert(t))
+				}
+			}
+			return r
+		},
+	}
+}
+
+func deriveString(t reflect.Type, tg tag) arbitrary {
+	lo, hi, sized := lengthBounds(tg, t)
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			n, r := chooseLength(rng, size, lo, hi, sized)
+			b := make([]byte, n)
+			for x := range b {
+				var i int
+				i, r = r.Intn(len(alphanumerics))
+				b[x] = alphanumerics[i]
+			}
+			return reflect.ValueOf(string(b)).Convert(t), r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			for _, c := range ShrinkString(v.String()) {
+				if len([]rune(c)) >= lo {
+					r = append(r, reflect.ValueOf(c).Convert(t))
+				}
+			}
+			return r
+		},
+	}
+}
+
+// Elements of A recursive type share the Size of their parent so that recursive values stay finite.
+func elementSize(recursive bool, size Size, n int) Size {
+	if recursive {
+		return size / (n + 1)
+	}
+	return size
+}
+
+func deriveSlice(t reflect.Type, tg tag, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	lo, hi, sized := lengthBounds(tg, t)
+	elem, rec := derive(t.Elem(), tag{}, inProgress), recursive(t)
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			n, r := chooseLength(rng, size, lo, hi, sized)
+			s := reflect.MakeSlice(t, n, n)
+			for x := 0; x < n; x++ {
+				var e reflect.Value
+				e, r = elem.run(r, elementSize(rec, size, n))
+				s.Index(x).Set(e)
+			}
+			return s, r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			if v.IsNil() {
+				return nil
+			}
+			var r []reflect.Value
+			for _, c := range ShrinkArray(elem.shrink)(elements(v)) {
+				if len(c) >= lo {
+					s := reflect.MakeSlice(t, len(c), len(c))
+					for x, e := range c {
+						s.Index(x).Set(e)
+					}
+					r = append(r, s)
+				}
+			}
+			return r
+		},
+	}
+}
+
+func elements(v reflect.Value) []reflect.Value {
+	var r []reflect.Value
+	for x := 0; x < v.Len(); x++ {
+		r = append(r, v.Index(x))
+	}
+	return r
+}
+
+func deriveArray(t reflect.Type, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	elem, rec := derive(t.Elem(), tag{}, inProgress), recursive(t)
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			a := reflect.New(t).Elem()
+			for x := 0; x < t.Len(); x++ {
+				var e reflect.Value
+				e, rng = elem.run(rng, elementSize(rec, size, t.Len()))
+				a.Index(x).Set(e)
+			}
+			return a, rng
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			for x := 0; x < v.Len(); x++ {
+				for _, e := range elem.shrink(v.Index(x)) {
+					c := reflect.New(t).Elem()
+					c.Set(v)
+					c.Index(x).Set(e)
+					r = append(r, c)
+				}
+			}
+			return r
+		},
+	}
+}
+
+// Generated keys may collide, so A map can have fewer entries than the length that was chosen, but never fewer than the tag's min
+// as long as there are enough distinct keys.
+func deriveMap(t reflect.Type, tg tag, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	lo, hi, sized := lengthBounds(tg, t)
+	key, elem, rec := derive(t.Key(), tag{}, inProgress), derive(t.Elem(), tag{}, inProgress), recursive(t)
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			n, r := chooseLength(rng, size, lo, hi, sized)
+			m := reflect.MakeMapWithSize(t, n)
+			for x := 0; x < n*MaxFilterTries && m.Len() < n; x++ {
+				var k, e reflect.Value
+				k, r = key.run(r, elementSize(rec, size, n))
+				e, r = elem.run(r, elementSize(rec, size, n))
+				m.SetMapIndex(k, e)
+			}
+			return m, r
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			keys := v.MapKeys()
+			if len(keys) > lo {
+				for _, k := range keys {
+					c := copyMap(v)
+					c.SetMapIndex(k, reflect.Value{})
+					r = append(r, c)
+				}
+			}
+			for _, k := range keys {
+				for _, e := range elem.shrink(v.MapIndex(k)) {
+					c := copyMap(v)
+					c.SetMapIndex(k, e)
+					r = append(r, c)
+				}
+			}
+			return r
+		},
+	}
+}
+
+func copyMap(v reflect.Value) reflect.Value {
+	c := reflect.MakeMapWithSize(v.Type(), v.Len())
+	for it := v.MapRange(); it.Next(); {
+		c.SetMapIndex(it.Key(), it.Value())
+	}
+	return c
+}
+
+func derivePointer(t reflect.Type, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	elem, rec := derive(t.Elem(), tag{}, inProgress), recursive(t)
+	return arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			i, r := rng.Intn(5)
+			if i == 0 || size == 0 && rec {
+				return reflect.Zero(t), r
+			}
+			e, r2 := elem.run(r, elementSize(rec, size, 1))
+			p := reflect.New(t.Elem())
+			p.Elem().Set(e)
+			return p, r2
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			if v.IsNil() {
+				return nil
+			}
+			r := []reflect.Value{reflect.Zero(t)}
+			for _, e := range elem.shrink(v.Elem()) {
+				p := reflect.New(t.Elem())
+				p.Elem().Set(e)
+				r = append(r, p)
+			}
+			return r
+		},
+	}
+}
+
+func deriveStruct(t reflect.Type, inProgress map[reflect.Type]*arbitrary) arbitrary {
+	if a, ok := inProgress[t]; ok {
+		return arbitrary{
+			run:    func(rng RNG, size Size) (reflect.Value, RNG) { return a.run(rng, size) },
+			shrink: func(v reflect.Value) []reflect.Value { return a.shrink(v) },
+		}
+	}
+	result := &arbitrary{}
+	inProgress[t] = result
+	fields := map[int]arbitrary{}
+	for x := 0; x < t.NumField(); x++ {
+		f := t.Field(x)
+		tg := parseTag(f.Tag.Get(TagKey))
+		if !f.IsExported() || tg.skip {
+			continue
+		}
+		fields[x] = derive(f.Type, tg, inProgress)
+	}
+	*result = arbitrary{
+		run: func(rng RNG, size Size) (reflect.Value, RNG) {
+			s := reflect.New(t).Elem()
+			for x := 0; x < t.NumField(); x++ {
+				if f, ok := fields[x]; ok {
+					var v reflect.Value
+					v, rng = f.run(rng, size)
+					s.Field(x).Set(v)
+				}
+			}
+			return s, rng
+		},
+		shrink: func(v reflect.Value) []reflect.Value {
+			var r []reflect.Value
+			for x := 0; x < t.NumField(); x++ {
+				f, ok := fields[x]
+				if !ok {
+					continue
+				}
+				for _, c := range f.shrink(v.Field(x)) {
+					s := reflect.New(t).Elem()
+					s.Set(v)
+					s.Field(x).Set(c)
+					r = append(r, s)
+				}
+			}
+			return r
+		},
+	}
+	return *result
+}
+
+// Whether A value of type t can contain another value of type t, e.g. A *Node whose Node has A Next *Node.
+func recursive(t reflect.Type) bool {
+	seen := map[reflect.Type]bool{}
+	var reaches func(u reflect.Type) bool
+	reaches = func(u reflect.Type) bool {
+		if seen[u] {
+			return false
+		}
+		seen[u] = true
+		var next []reflect.Type
+		switch u.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Pointer:
+			next = []reflect.Type{u.Elem()}
+		case reflect.Map:
+			next = []reflect.Type{u.Key(), u.Elem()}
+		case reflect.Struct:
+			for x := 0; x < u.NumField(); x++ {
+				next = append(next, u.Field(x).Type)
+			}
+		}
+		for _, n := range next {
+			if n == t || reaches(n) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(t)
+}
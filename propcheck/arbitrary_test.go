@@ -0,0 +1,226 @@
+package propcheck
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Street string `propcheck:"min=1,max=20"`
+	Zip    uint16
+}
+
+type customer struct {
+	Name      string  `propcheck:"min=1,max=10"`
+	Age       int     `propcheck:"min=0,max=130"`
+	Score     float64 `propcheck:"min=0,max=1"`
+	Active    bool
+	Tags      []string `propcheck:"max=3"`
+	Home      *address
+	Addresses map[string]address
+	Codes     [3]int8
+	Ignored   chan int `propcheck:"-"`
+	secret    func()
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func (n *node) length() int {
+	if n == nil {
+		return 0
+	}
+	return 1 + n.Next.length()
+}
+
+type tree struct {
+	Children []tree
+}
+
+func TestArbitraryHonorsTags(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(Arbitrary[customer](), "Generated customers satisfy their tags",
+		func(c customer) customer { return c },
+		func(c customer) (bool, error) {
+			var errors []string
+			if len(c.Name) < 1 || len(c.Name) > 10 {
+				errors = append(errors, fmt.Sprintf("Name %q had the wrong length", c.Name))
+			}
+			if c.Age < 0 || c.Age > 130 {
+				errors = append(errors, fmt.Sprintf("Age %v was out of range", c.Age))
+			}
+			if c.Score < 0 || c.Score > 1 {
+				errors = append(errors, fmt.Sprintf("Score %v was out of range", c.Score))
+			}
+			if len(c.Tags) > 3 {
+				errors = append(errors, fmt.Sprintf("Tags %v had too many elements", c.Tags))
+			}
+			if c.Home != nil && (len(c.Home.Street) < 1 || len(c.Home.Street) > 20) {
+				errors = append(errors, fmt.Sprintf("Street %q had the wrong length", c.Home.Street))
+			}
+			if c.Ignored != nil || c.secret != nil {
+				errors = append(errors, "Skipped and unexported fields should have been left as zero values")
+			}
+			if len(errors) > 0 {
+				return false, fmt.Errorf("%v", strings.Join(errors, ", "))
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	ExpectSuccess[customer](t, result)
+}
+
+func TestArbitraryGeneratesVariedValues(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var nilHomes, homes, active, negativeCodes, addresses int
+	for _, c := range Arbitrary[customer]().Sample(rng, 200) {
+		if c.Home == nil {
+			nilHomes++
+		} else {
+			homes++
+		}
+		if c.Active {
+			active++
+		}
+		if c.Codes[0] < 0 {
+			negativeCodes++
+		}
+		addresses += len(c.Addresses)
+	}
+	if nilHomes == 0 || homes == 0 || active == 0 || active == 200 || negativeCodes == 0 || addresses == 0 {
+		t.Errorf("Customers should have varied but there were %v nil homes, %v homes, %v active, %v negative codes and %v addresses",
+			nilHomes, homes, active, negativeCodes, addresses)
+	}
+}
+
+func TestArbitraryShrinksFieldByField(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(Arbitrary[customer](), "Customers are younger than 50",
+		func(c customer) customer { return c },
+		func(c customer) (bool, error) {
+			if c.Age >= 50 {
+				return false, fmt.Errorf("%v was too old", c.Age)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	f, ok := result.(Falsified[customer])
+	if !ok {
+		t.Fatalf("Property should have been falsified but was %v", result)
+	}
+	c := f.FailedCase
+	if c.Age != 50 || len(c.Name) != 1 || c.Score != 0 || c.Active || len(c.Tags) != 0 || c.Home != nil || len(c.Addresses) != 0 || c.Codes != [3]int8{} {
+		t.Errorf("The counterexample should have shrunk to a customer aged 50 with every other field minimal but was %+v", c)
+	}
+}
+
+func TestArbitraryGeneratesRecursiveTypes(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	longest := 0
+	for _, n := range Arbitrary[*node]().Sample(rng, 100) {
+		longest = max(longest, n.length())
+	}
+	if longest < 2 {
+		t.Errorf("Some linked lists should have had more than one node but the longest had %v", longest)
+	}
+	var count func(tree) int
+	count = func(t tree) int {
+		n := 1
+		for _, c := range t.Children {
+			n += count(c)
+		}
+		return n
+	}
+	for _, tr := range Arbitrary[tree]().Sample(rng, 20) {
+		if n := count(tr); n > 10*DefaultMaxSize {
+			t.Errorf("A tree of Size %v should not have had %v nodes", DefaultMaxSize, n)
+		}
+	}
+}
+
+func TestArbitraryFloatWithOneBoundIsFinite(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	type bounded struct {
+		Low  float64 `propcheck:"min=1000"`
+		High float32 `propcheck:"max=-1000"`
+	}
+	for _, b := range Arbitrary[bounded]().Sample(rng, 100) {
+		if math.IsInf(b.Low, 0) || math.IsNaN(b.Low) || b.Low < 1000 || b.Low > 1000+float64(DefaultMaxSize) {
+			t.Errorf("A min-only float should have been in [1000, %v] but was %v", 1000+DefaultMaxSize, b.Low)
+		}
+		if math.IsInf(float64(b.High), 0) || math.IsNaN(float64(b.High)) || b.High > -1000 || b.High < -1000-float32(DefaultMaxSize) {
+			t.Errorf("A max-only float should have been in [%v, -1000] but was %v", -1000-DefaultMaxSize, b.High)
+		}
+	}
+}
+
+type celsius float64
+
+// Registers g for the duration of the test, restoring whatever was registered for T before, so that no other test sees it.
+func registerForTest[T any](t *testing.T, g Gen[T]) {
+	registry.RLock()
+	old, ok := registry.gens[typeOf[T]()]
+	registry.RUnlock()
+	Register(g)
+	t.Cleanup(func() {
+		registry.Lock()
+		defer registry.Unlock()
+		if ok {
+			registry.gens[typeOf[T]()] = old
+		} else {
+			delete(registry.gens, typeOf[T]())
+		}
+	})
+}
+
+func TestRegisterOverridesDerivedGen(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	registerForTest(t, Map(ChooseInt(-273, 100), func(i int) celsius { return celsius(i) }))
+	type reading struct {
+		Temperatures []celsius
+		Describe     fmt.Stringer
+	}
+	registerForTest[fmt.Stringer](t, Id[fmt.Stringer](time.Second))
+	for _, r := range Arbitrary[reading]().Sample(rng, 50) {
+		for _, c := range r.Temperatures {
+			if c < -273 || c >= 100 || c != celsius(int(c)) {
+				t.Errorf("%v should have come from the registered Gen", c)
+			}
+		}
+		if r.Describe != time.Second {
+			t.Errorf("The registered Gen of an interface should have been used but was %v", r.Describe)
+		}
+	}
+}
+
+func TestArbitraryPanicsForUnsupportedTypes(t *testing.T) {
+	for name, f := range map[string]func(){
+		"interface": func() { Arbitrary[error]() },
+		"bad tag": func() {
+			Arbitrary[struct {
+				X int `propcheck:"min=ten"`
+			}]()
+		},
+		"empty range": func() {
+			Arbitrary[struct {
+				X int `propcheck:"min=10,max=1"`
+			}]()
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Arbitrary should have panicked for the %v", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,98 @@
+package propcheck
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-test/deep"
+)
+
+// Reports whether got == want, in the (bool, error) shape of the assertions given to ForAll, so that an assertion can end with
+// "return propcheck.Equal(got, want)". On A mismatch the error lists the fields that differ.
+func Equal[T comparable](got, want T) (bool, error) {
+	if got == want {
+		return true, nil
+	}
+	return false, mismatch(got, want)
+}
+
+// Reports whether got and want are deeply equal as defined by reflect.DeepEqual. On A mismatch the error lists the fields, elements and
+// map entries that differ, e.g. "[2]: 3 != 4".
+func DeepEqual(got, want any) (bool, error) {
+	if reflect.DeepEqual(got, want) {
+		return true, nil
+	}
+	return false, mismatch(got, want)
+}
+
+// The error for got differing from want, listing each difference found by deep.Equal, or both values when it finds none, as happens
+// for unexported fields.
+func mismatch(got, want any) error {
+	diffs := deep.Equal(got, want)
+	if len(diffs) == 0 {
+		return fmt.Errorf("got %#v but wanted %#v", got, want)
+	}
+	return fmt.Errorf("got %#v but wanted %#v:\n  %v", got, want, strings.Join(diffs, "\n  "))
+}
+
+// Reports whether got and want have the same elements, compared with reflect.DeepEqual, the same number of times in any order.
+// On A mismatch the error lists the elements that are missing from got and those that are extra.
+func ElementsMatch[T any](got, want []T) (bool, error) {
+	extra := append([]T{}, got...)
+	var missing []T
+	for _, w := range want {
+		i := indexOf(extra, w)
+		if i < 0 {
+			missing = append(missing, w)
+			continue
+		}
+		extra = append(extra[:i], extra[i+1:]...)
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return true, nil
+	}
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing %#v", missing))
+	}
+	if len(extra) > 0 {
+		problems = append(problems, fmt.Sprintf("extra %#v", extra))
+	}
+	return false, fmt.Errorf("the elements of %#v did not match %#v: %v", got, want, strings.Join(problems, ", "))
+}
+
+// The index of the first element of xs deeply equal to x, or -1 if there is none.
+func indexOf[T any](xs []T, x T) int {
+	for i, y := range xs {
+		if reflect.DeepEqual(x, y) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reports whether a < b.
+func Less[T cmp.Ordered](a, b T) (bool, error) {
+	if a < b {
+		return true, nil
+	}
+	return false, fmt.Errorf("%#v was not less than %#v", a, b)
+}
+
+// Reports whether xs has an element deeply equal to x.
+func Contains[T any](xs []T, x T) (bool, error) {
+	if indexOf(xs, x) >= 0 {
+		return true, nil
+	}
+	return false, fmt.Errorf("%#v did not contain %#v", xs, x)
+}
+
+// Reports whether p holds for a. On failure the error says that a did not satisfy the description, e.g. Satisfies(x, "is even", isEven).
+func Satisfies[T any](a T, description string, p func(T) bool) (bool, error) {
+	if p(a) {
+		return true, nil
+	}
+	return false, fmt.Errorf("%#v did not satisfy %q", a, description)
+}
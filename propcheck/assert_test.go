@@ -0,0 +1,82 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+	Tags []string
+}
+
+func expectAssertion(t *testing.T, name string, ok bool, err error, expectOk bool, expectedErr string) {
+	t.Helper()
+	if ok != expectOk || (err == nil) != expectOk || (err != nil && !strings.Contains(err.Error(), expectedErr)) {
+		t.Errorf("%v should have returned %v with an error containing %q but returned %v, %v", name, expectOk, expectedErr, ok, err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	ok, err := Equal(3, 3)
+	expectAssertion(t, "Equal(3, 3)", ok, err, true, "")
+	ok, err = Equal(struct{ X, Y int }{1, 2}, struct{ X, Y int }{1, 3})
+	expectAssertion(t, "Equal of structs", ok, err, false, "Y: 2 != 3")
+}
+
+func TestDeepEqual(t *testing.T) {
+	ok, err := DeepEqual(point{1, 2, []string{"a"}}, point{1, 2, []string{"a"}})
+	expectAssertion(t, "DeepEqual of equal points", ok, err, true, "")
+	ok, err = DeepEqual(point{1, 2, []string{"a", "b"}}, point{1, 5, []string{"a", "c"}})
+	expectAssertion(t, "DeepEqual of different points", ok, err, false, "\n  Y: 2 != 5\n  Tags.slice[1]: b != c")
+	ok, err = DeepEqual(struct{ x int }{1}, struct{ x int }{2})
+	expectAssertion(t, "DeepEqual of unexported fields", ok, err, false, "got struct { x int }{x:1} but wanted struct { x int }{x:2}")
+}
+
+func TestElementsMatch(t *testing.T) {
+	ok, err := ElementsMatch([]int{3, 1, 2, 1}, []int{1, 1, 2, 3})
+	expectAssertion(t, "ElementsMatch of a permutation", ok, err, true, "")
+	ok, err = ElementsMatch([]int{3, 1, 2, 2}, []int{1, 1, 2, 3})
+	expectAssertion(t, "ElementsMatch of different counts", ok, err, false, "missing []int{1}, extra []int{2}")
+	ok, err = ElementsMatch([][]int{{1}}, [][]int{{1}, {2}})
+	expectAssertion(t, "ElementsMatch of a missing element", ok, err, false, "missing [][]int{[]int{2}}")
+}
+
+func TestLessContainsSatisfies(t *testing.T) {
+	ok, err := Less("a", "b")
+	expectAssertion(t, "Less", ok, err, true, "")
+	ok, err = Less(2.5, 2.5)
+	expectAssertion(t, "Less of equal numbers", ok, err, false, "2.5 was not less than 2.5")
+	ok, err = Contains([]string{"a", "b"}, "b")
+	expectAssertion(t, "Contains", ok, err, true, "")
+	ok, err = Contains([]string{"a", "b"}, "c")
+	expectAssertion(t, "Contains of a missing element", ok, err, false, `[]string{"a", "b"} did not contain "c"`)
+	isEven := func(x int) bool { return x%2 == 0 }
+	ok, err = Satisfies(4, "is even", isEven)
+	expectAssertion(t, "Satisfies", ok, err, true, "")
+	ok, err = Satisfies(3, "is even", isEven)
+	expectAssertion(t, "Satisfies of an odd number", ok, err, false, `3 did not satisfy "is even"`)
+}
+
+func TestAssertionsInForAll(t *testing.T) {
+	prop := ForAll(ChooseArray(0, 10, ChooseInt(0, 100)), "Filtering nothing out keeps the elements",
+		func(xs []int) Pair[[]int, []int] { return Pair[[]int, []int]{xs, dropFirstOdd(xs)} },
+		func(p Pair[[]int, []int]) (bool, error) { return ElementsMatch(p.B, p.A) },
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: SimpleRNG{Seed: 13634551}})
+	ExpectFailure[[]int](t, result)
+	if f := result.(Falsified[[]int]); !strings.Contains(fmt.Sprint(f.Errors), "missing []int{1}") {
+		t.Errorf("The counterexample should have shrunk to a missing 1 but the errors were %v", f.Errors)
+	}
+}
+
+// A broken filter that drops the first odd element when it should drop nothing.
+func dropFirstOdd(xs []int) []int {
+	for i, x := range xs {
+		if x%2 == 1 {
+			return append(append([]int{}, xs[:i]...), xs[i+1:]...)
+		}
+	}
+	return xs
+}
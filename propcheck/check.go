@@ -0,0 +1,228 @@
+package propcheck
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// An Option configures how Check runs A property.
+type Option func(*checkOptions)
+
+type checkOptions struct {
+	parms         RunParms
+	seeded        bool
+	regressionDir string  //Where falsifying seeds are recorded and replayed from, or "" not to.
+	printer       Printer //Formats counterexamples in PropertyReports.
+}
+
+// Runs n test cases instead of DefaultTestCases.
+func WithTestCases(n TestCases) Option {
+	return func(o *checkOptions) {
+		o.parms.TestCases = n
+	}
+}
+
+// Runs the property with the given RNG. A seed supplied with PROPCHECK_SEED or -propcheck.seed still takes precedence
+// so that the replay command printed on failure always works.
+func WithRNG(rng RNG) Option {
+	return func(o *checkOptions) {
+		o.parms.Rng = rng
+		o.seeded = true
+	}
+}
+
+// Runs the property with A SimpleRNG made from the given seed. See WithRNG.
+func WithSeed(seed int) Option {
+	return WithRNG(SimpleRNG{Seed: seed})
+}
+
+// Sets the Size given to generators for the last test case. See Sized.
+func WithMaxSize(size Size) Option {
+	return func(o *checkOptions) {
+		o.parms.MaxSize = size
+	}
+}
+
+// Evaluates test cases with n Go Routines. See ForAll for the thread-safety this requires of assertions.
+func WithWorkers(n int) Option {
+	return func(o *checkOptions) {
+		o.parms.Workers = n
+	}
+}
+
+// Allows n discarded test cases per test case before giving up instead of DefaultMaxDiscardRatio. See SuchThat and Implies.
+func WithMaxDiscardRatio(n int) Option {
+	return func(o *checkOptions) {
+		o.parms.MaxDiscardRatio = n
+	}
+}
+
+// Formats the counterexamples in the PropertyReports Check writes with printer instead of GoSyntax.
+func WithPrinter(printer Printer) Option {
+	return func(o *checkOptions) {
+		o.printer = printer
+	}
+}
+
+// Records and replays the seeds that falsified the property in dir instead of RegressionDir.
+func WithRegressionDir(dir string) Option {
+	return func(o *checkOptions) {
+		o.regressionDir = dir
+	}
+}
+
+// Neither replays nor records the seeds that falsified the property. See RegressionDir.
+func WithoutRegressions() Option {
+	return func(o *checkOptions) {
+		o.regressionDir = ""
+	}
+}
+
+/*
+*
+Check runs the property p as A subtest of t named after the property and fails the subtest with A readable, multi-line report
+when the property is falsified. Unlike ExpectSuccess it needs no type parameter.
+
+By default Check runs DefaultTestCases test cases with the RNG from NewRNG. Use Options to change that.
+
+Before drawing A new seed Check replays the seeds that falsified the property before, which are recorded in RegressionDir, and it records
+the seed of A new failure there. A seed supplied with PROPCHECK_SEED or -propcheck.seed is run on its own so that it replays exactly.
+
+Check also writes A PropertyReport of every property as JSON or JUnit XML when PROPCHECK_REPORT or -propcheck.report asks for one.
+*/
+func Check(t *testing.T, p Prop, opts ...Option) {
+	t.Helper()
+	o := checkOptions{parms: RunParms{TestCases: DefaultTestCases}, regressionDir: RegressionDir}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	_, supplied := suppliedSeed()
+	if supplied || !o.seeded {
+		o.parms.Rng = NewRNG()
+	}
+	check := func(t *testing.T) {
+		t.Helper()
+		start := time.Now()
+		path := regressionPath(o.regressionDir, t.Name())
+		var result Result
+		report, ok := "", true
+		if o.regressionDir != "" && !supplied {
+			result, report, ok = replayRegressions(t.Name(), p, o.parms, path)
+		}
+		if ok {
+			result, report, ok = runCheck(t.Name(), p, o.parms)
+			if c, falsified := result.(counterexample); !ok && falsified && o.regressionDir != "" {
+				if err := saveRegression(path, o.parms.Rng, c.counterexample()); err != nil {
+					report += fmt.Sprintf("\n  Recorded:        no, %v", err)
+				} else {
+					report += fmt.Sprintf("\n  Recorded:        in %v, to be replayed by every run", path)
+				}
+			}
+		}
+		if err := collectReport(NewPropertyReport(t.Name(), p.Name, result, time.Since(start), o.printer)); err != nil {
+			t.Errorf("Could not write the propcheck report: %v", err)
+		}
+		if !ok {
+			t.Fatal(report)
+		}
+		if report != "" {
+			t.Log(report)
+		}
+	}
+	if p.Name == "" {
+		check(t)
+	} else {
+		t.Run(p.Name, check)
+	}
+}
+
+// Runs the property and returns its Result and A report on it along with whether it passed. The report of A passing property may be empty.
+func runCheck(testName string, p Prop, parms RunParms) (Result, string, bool) {
+	result := p.Run(parms)
+	if result == nil {
+		return nil, fmt.Sprintf("Property %q did not produce a result", p.Name), false
+	}
+	r, ok := result.(reporter)
+	if _, gaveUp := result.(inconclusive); !result.IsFalsified() && !gaveUp {
+		if ok {
+			return result, r.report(""), true
+		}
+		return result, "", true
+	}
+	if ok {
+		return result, r.report(replayCommandFor(testName, parms.Rng)), false
+	}
+	return result, fmt.Sprintf("Property %q was falsified: %v", p.Name, result), false
+}
+
+// Implemented by Results that can describe themselves in A Check failure.
+type reporter interface {
+	report(replay string) string
+}
+
+// Reports the distribution of labels and the number of discarded test cases, if there are any.
+func (w Passed[A]) report(string) string {
+	if len(w.Labels) == 0 && w.Discards == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("Passed %v test cases", w.TestCases)
+	if w.Discards > 0 {
+		s += fmt.Sprintf(" (%v discarded)", w.Discards)
+	}
+	if len(w.Labels) == 0 {
+		return s + "."
+	}
+	return s + ":\n  " + strings.Join(histogram(w.Labels, w.TestCases), "\n  ")
+}
+
+func (w Falsified[A]) report(replay string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Property %q falsified after %v successful test cases", strings.TrimSpace(w.Name), w.Successes)
+	if w.Shrinks > 0 {
+		fmt.Fprintf(&b, " and %v shrinks", w.Shrinks)
+	}
+	if w.Discards > 0 {
+		fmt.Fprintf(&b, " (%v discarded)", w.Discards)
+	}
+	b.WriteString(".\n")
+	fmt.Fprintf(&b, "  Seed:            %v\n", w.Seed)
+	fmt.Fprintf(&b, "  Counterexample:  %v\n", w.FailedCase)
+	if w.Successes > 0 {
+		fmt.Fprintf(&b, "  Last success:    %v\n", w.LastSuccessCase)
+	}
+	if w.Errors != nil {
+		fmt.Fprintf(&b, "  Errors:          %v\n", indent(w.Errors.Error(), "                   "))
+	}
+	if w.Panic != nil {
+		fmt.Fprintf(&b, "  Panic:           %v\n", w.Panic)
+		fmt.Fprintf(&b, "  Stack:           %v\n", indent(w.Stack, "                   "))
+	}
+	fmt.Fprintf(&b, "  Replay:          %v", replay)
+	return b.String()
+}
+
+// A replay command that re-runs just the named test with the seed of rng.
+func replayCommandFor(testName string, rng RNG) string {
+	if testName == "" {
+		return ReplayCommand(rng)
+	}
+	seed, _ := FormatSeed(rng)
+	return fmt.Sprintf("%v=%v go test -count=1 -run '%v' .", SeedEnv, seed, runPattern(testName))
+}
+
+// A -run pattern that matches just the named test, quoted for A single-quoted shell argument.
+func runPattern(testName string) string {
+	var parts []string
+	for _, p := range strings.Split(testName, "/") {
+		parts = append(parts, "^"+regexp.QuoteMeta(p)+"$")
+	}
+	return strings.ReplaceAll(strings.Join(parts, "/"), "'", `'\''`)
+}
+
+// Indents every line but the first of A multi-line string.
+func indent(s, prefix string) string {
+	return strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n"+prefix)
+}
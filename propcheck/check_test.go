@@ -0,0 +1,87 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckPassingProperty(t *testing.T) {
+	prop := ForAll(ChooseInt(1, 501), "Numbers must not be larger than 500",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x > 500 {
+				return false, fmt.Errorf("%v was too large", x)
+			}
+			return true, nil
+		},
+	)
+	Check(t, prop, WithTestCases(200))
+}
+
+func TestCheckUsesOptions(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "")
+	var parms RunParms
+	prop := Prop{
+		Run: func(p RunParms) Result {
+			parms = p
+			return Passed[int]{}
+		},
+	}
+	Check(t, prop, WithTestCases(7), WithSeed(13634551), WithMaxSize(3))
+	if parms.TestCases != 7 || parms.Rng != (SimpleRNG{Seed: 13634551}) || parms.MaxSize != 3 {
+		t.Errorf("Check should have applied its options but ran with %v", parms)
+	}
+}
+
+func TestCheckPrefersSuppliedSeedToOption(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "42")
+	var parms RunParms
+	prop := Prop{
+		Run: func(p RunParms) Result {
+			parms = p
+			return Passed[int]{}
+		},
+	}
+	Check(t, prop, WithSeed(13634551))
+	if parms.Rng != (SimpleRNG{Seed: 42}) {
+		t.Errorf("The seed from %v should have taken precedence but ran with %v", SeedEnv, parms.Rng)
+	}
+}
+
+func TestCheckReport(t *testing.T) {
+	rng := SimpleRNG{Seed: 13634551}
+	prop := ForAll(ChooseInt(0, 1000), "Numbers must be less than 100",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x >= 100 {
+				return false, fmt.Errorf("%v was too large", x)
+			}
+			return true, nil
+		},
+	)
+	_, report, ok := runCheck("TestX/Numbers_must_be_less_than_100", prop, RunParms{TestCases: 200, Rng: rng})
+	if ok {
+		t.Fatalf("Property should have been falsified")
+	}
+	for _, expected := range []string{
+		`Property "Numbers must be less than 100" falsified`,
+		"Counterexample:  100\n",
+		"Seed:            SimpleRMG{Seed: 13634551}",
+		"100 was too large",
+		"PROPCHECK_SEED=13634551 go test -count=1 -run '^TestX$/^Numbers_must_be_less_than_100$' .",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report should have contained %q but was:\n%v", expected, report)
+		}
+	}
+}
+
+func TestCheckReportsNilResult(t *testing.T) {
+	prop := Prop{Run: func(RunParms) Result { return nil }, Name: "nil"}
+	if _, _, ok := runCheck("TestX", prop, RunParms{TestCases: 1}); ok {
+		t.Errorf("A nil Result should not have passed")
+	}
+}
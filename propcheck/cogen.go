@@ -0,0 +1,96 @@
+package propcheck
+
+import (
+	"math"
+)
+
+/*
+*
+A Cogen perturbs an RNG by A value of type A, so that equal values give the same RNG and different values almost always give different ones.
+It is the dual of A Gen: A Gen turns an RNG into A value, A Cogen turns A value into an RNG. FunOf uses A Cogen for the arguments of A
+function and A Gen for its results to generate random but deterministic functions.
+
+Make A Cogen for your own types with CogenConvert, e.g. for A struct:
+
+	cogen := propcheck.CogenConvert(propcheck.CogenPair(propcheck.CogenString(), propcheck.CogenInteger[int]()),
+		func(p Person) propcheck.Pair[string, int] { return propcheck.Pair[string, int]{A: p.Name, B: p.Age} })
+*/
+type Cogen[A any] func(a A, rng RNG) RNG
+
+// Perturbs the rng by n by taking one side of A Split for every bit of n. Each bit is preceded by A Split that continues and n ends with
+// A Split that stops, so that the encodings of two numbers are never prefixes of one another and values can be perturbed one after
+// another, as CogenArray does. Small numbers take few Splits.
+func variant(n uint64, rng RNG) RNG {
+	for {
+		stop, more := rng.Split()
+		if n == 0 {
+			return stop
+		}
+		zero, one := more.Split()
+		if n&1 == 0 {
+			rng = zero
+		} else {
+			rng = one
+		}
+		n = n >> 1
+	}
+}
+
+// A Cogen of any integer type. Negative numbers are zigzag encoded so that numbers near zero perturb the RNG quickly.
+func CogenInteger[T Integral]() Cogen[T] {
+	lo, _ := integerBounds[T]()
+	return func(x T, rng RNG) RNG {
+		if lo < 0 {
+			i := int64(x)
+			return variant(uint64(i)<<1^uint64(i>>63), rng)
+		}
+		return variant(uint64(x), rng)
+	}
+}
+
+// A Cogen of booleans.
+func CogenBool() Cogen[bool] {
+	return func(b bool, rng RNG) RNG {
+		if b {
+			return variant(1, rng)
+		}
+		return variant(0, rng)
+	}
+}
+
+// A Cogen of any floating point type, which perturbs the RNG by the bits of the number.
+func CogenFloat[T Floating]() Cogen[T] {
+	return func(x T, rng RNG) RNG {
+		return variant(math.Float64bits(float64(x)), rng)
+	}
+}
+
+// A Cogen of strings, which perturbs the RNG by their bytes.
+func CogenString() Cogen[string] {
+	return CogenConvert(CogenArray(CogenInteger[byte]()), func(s string) []byte { return []byte(s) })
+}
+
+// A Cogen of arrays, which perturbs the RNG by the length of the array and then by each element with the given Cogen.
+func CogenArray[T any](elem Cogen[T]) Cogen[[]T] {
+	return func(xs []T, rng RNG) RNG {
+		rng = variant(uint64(len(xs)), rng)
+		for _, x := range xs {
+			rng = elem(x, rng)
+		}
+		return rng
+	}
+}
+
+// A Cogen of Pairs, which perturbs the RNG by the A side and then by the B side.
+func CogenPair[A, B any](ca Cogen[A], cb Cogen[B]) Cogen[Pair[A, B]] {
+	return func(p Pair[A, B], rng RNG) RNG {
+		return cb(p.B, ca(p.A, rng))
+	}
+}
+
+// Adapts A Cogen of B to A Cogen of A given A function from A to B. Values of A that f maps to the same B perturb the RNG the same way.
+func CogenConvert[A, B any](c Cogen[B], f func(A) B) Cogen[A] {
+	return func(a A, rng RNG) RNG {
+		return c(f(a), rng)
+	}
+}
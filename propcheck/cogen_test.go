@@ -0,0 +1,44 @@
+package propcheck
+
+import (
+	"testing"
+	"time"
+)
+
+func perturbed[A any](c Cogen[A], a A, rng RNG) uint64 {
+	u, _ := c(a, rng).Uint64()
+	return u
+}
+
+func TestCogenIsDeterministic(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, s := range []string{"", "a", "hello"} {
+		if perturbed(CogenString(), s, rng) != perturbed(CogenString(), s, rng) {
+			t.Errorf("%q should have perturbed the RNG the same way every time", s)
+		}
+	}
+}
+
+func TestCogenDistinguishesValues(t *testing.T) {
+	for _, rng := range []RNG{SimpleRNG{Seed: time.Now().Nanosecond()}, SplitMix64{State: uint64(time.Now().UnixNano())}} {
+		seen := map[uint64]int{}
+		for x := -500; x < 500; x++ {
+			seen[perturbed(CogenInteger[int](), x, rng)] = x
+		}
+		if len(seen) != 1000 {
+			t.Errorf("1000 ints should have perturbed %v 1000 ways but only did %v", rng, len(seen))
+		}
+		arrays := [][]int{{}, {0}, {0, 0}, {1}, {1, 0}, {0, 1}, {2}, {1, 1}}
+		seenArrays := map[uint64]bool{}
+		for _, xs := range arrays {
+			seenArrays[perturbed(CogenArray(CogenInteger[int]()), xs, rng)] = true
+		}
+		if len(seenArrays) != len(arrays) {
+			t.Errorf("%v arrays should have perturbed %v in as many ways but only did %v", len(arrays), rng, len(seenArrays))
+		}
+		p := CogenPair(CogenBool(), CogenFloat[float64]())
+		if perturbed(p, Pair[bool, float64]{true, 0.5}, rng) == perturbed(p, Pair[bool, float64]{false, 0.5}, rng) {
+			t.Errorf("Pairs that differ should have perturbed %v differently", rng)
+		}
+	}
+}
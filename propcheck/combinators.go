@@ -0,0 +1,145 @@
+package propcheck
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Generates A value from one of the given Gens, each equally likely. See Frequency.
+func OneOf[A any](gens ...Gen[A]) Gen[A] {
+	var w []WeightedGen[A]
+	for _, g := range gens {
+		w = append(w, WeightedGen[A]{Gen: g, Weight: 1})
+	}
+	return Frequency(w...)
+}
+
+/*
+*
+Generates A value from one of the given Gens in proportion to its weight: A Gen of weight 3 is chosen three times as often as one of weight 1,
+and A Gen of weight 0 is never chosen. The choice takes time logarithmic in the number of Gens however large the weights are, and the chosen
+Gen runs with A different RNG than the one that made the choice.
+
+The Gen does not know which Gen produced A failing value, so it shrinks with the Shrinker of every Gen in turn. Frequency panics if there are
+no Gens, A weight is negative or every weight is zero.
+
+	op := propcheck.Frequency(
+		propcheck.WeightedGen[string]{Gen: propcheck.Id("get"), Weight: 8},
+		propcheck.WeightedGen[string]{Gen: propcheck.Id("put"), Weight: 2},
+	)
+*/
+func Frequency[A any](gens ...WeightedGen[A]) Gen[A] {
+	var cumulative []int //The running total of the weights, so that gens[i] is chosen for A draw in [cumulative[i-1], cumulative[i]).
+	total := 0
+	for _, g := range gens {
+		if g.Weight < 0 {
+			panic(fmt.Sprintf("Frequency needs weights >= 0 but was given %v", g.Weight))
+		}
+		total = total + g.Weight
+		cumulative = append(cumulative, total)
+	}
+	if total == 0 {
+		panic("Frequency needs at least one Gen with A positive weight")
+	}
+	g := genWithSize(func(rng RNG, size Size) (A, RNG) {
+		i, r := rng.Intn(total)
+		chosen := sort.Search(len(cumulative), func(x int) bool { return cumulative[x] > i })
+		return gens[chosen].Gen.run(r, size)
+	})
+	var shrinkers []Shrinker[A]
+	for _, w := range gens {
+		if w.Gen.shrink != nil && w.Weight > 0 {
+			shrinkers = append(shrinkers, w.Gen.shrink)
+		}
+	}
+	if len(shrinkers) == 0 {
+		return g
+	}
+	return g.WithShrinker(func(a A) []A {
+		var r []A
+		for _, s := range shrinkers {
+			r = append(r, s(a)...)
+		}
+		return r
+	})
+}
+
+// Generates one of the given values, each equally likely. The Gen shrinks toward the values that come first.
+// Elements panics if there are no values.
+func Elements[A any](values ...A) Gen[A] {
+	if len(values) == 0 {
+		panic("Elements needs at least one value")
+	}
+	g := NewGen(func(rng RNG) (A, RNG) {
+		i, r := rng.Intn(len(values))
+		return values[i], r
+	})
+	return g.WithShrinker(func(a A) []A {
+		var r []A
+		for _, v := range values {
+			if reflect.DeepEqual(v, a) {
+				break
+			}
+			r = append(r, v)
+		}
+		if len(r) == len(values) { //A is not one of the values.
+			return nil
+		}
+		return r
+	})
+}
+
+/*
+*
+Defers making A Gen until it is first needed, which lets A Gen refer to itself before it has been assigned. The Gen made by f is reused
+after that. A self-referential Gen must stop recursing on its own, for instance by choosing A base case often enough that A value has
+less than one recursive part on average. Recursive bounds the depth for you.
+
+	var tree propcheck.Gen[Tree]
+	subtree := propcheck.Lazy(func() propcheck.Gen[Tree] { return tree })
+	tree = propcheck.Frequency(
+		propcheck.WeightedGen[Tree]{Gen: leaf, Weight: 3},
+		propcheck.WeightedGen[Tree]{Gen: propcheck.Map2(subtree, subtree, newBranch), Weight: 1},
+	)
+*/
+func Lazy[A any](f func() Gen[A]) Gen[A] {
+	var once sync.Once
+	var g Gen[A]
+	force := func() Gen[A] {
+		once.Do(func() { g = f() })
+		return g
+	}
+	return genWithSize(func(rng RNG, size Size) (A, RNG) {
+		return force().run(rng, size)
+	}).WithShrinker(func(a A) []A {
+		if s := force().shrink; s != nil {
+			return s(a)
+		}
+		return nil
+	})
+}
+
+/*
+*
+Generates A value of A recursive type, such as A tree or an AST, that is nested at most depth levels deep. The Gen at depth 0 is base,
+and the Gen at each level above is f applied to the Gen one level below. f typically chooses between base and A Gen that combines
+several values from the Gen it is given:
+
+	expr := propcheck.Recursive(3, literal, func(sub propcheck.Gen[Expr]) propcheck.Gen[Expr] {
+		return propcheck.OneOf(literal, propcheck.Map2(sub, sub, newAdd), propcheck.Map(sub, newNegate))
+	})
+
+Recursive panics if depth is negative.
+*/
+func Recursive[A any](depth int, base Gen[A], f func(Gen[A]) Gen[A]) Gen[A] {
+	if depth < 0 {
+		panic(fmt.Sprintf("Recursive needs A depth >= 0 but was given %v", depth))
+	}
+	g := base
+	for x := 0; x < depth; x++ {
+		g = f(g)
+	}
+	return g
+}
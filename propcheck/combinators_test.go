@@ -0,0 +1,139 @@
+package propcheck
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+type expr struct {
+	value       int
+	left, right *expr
+}
+
+func (e expr) depth() int {
+	if e.left == nil {
+		return 0
+	}
+	return 1 + max(e.left.depth(), e.right.depth())
+}
+
+var literal = Map(ChooseInt(0, 10), func(x int) expr { return expr{value: x} })
+
+func add(l, r expr) expr {
+	return expr{left: &l, right: &r}
+}
+
+func TestFrequencyChoosesInProportionToWeight(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := Frequency(WeightedGen[string]{Gen: Id("a"), Weight: 1}, WeightedGen[string]{Gen: Id("b"), Weight: 0},
+		WeightedGen[string]{Gen: Id("c"), Weight: 3})
+	counts := map[string]int{}
+	for _, s := range g.Sample(rng, 4000) {
+		counts[s]++
+	}
+	if counts["b"] != 0 || counts["a"] < 800 || counts["a"] > 1200 || counts["c"] < 2800 || counts["c"] > 3200 {
+		t.Errorf("A weight of 1 should have been chosen about 1000 times, 0 never and 3 about 3000 times but were %v", counts)
+	}
+}
+
+func TestFrequencyHandlesLargeWeights(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := Weighted([]WeightedGen[int]{{Gen: Id(1), Weight: math.MaxInt32}, {Gen: Id(2), Weight: math.MaxInt32}})
+	seen := map[int]bool{}
+	for _, x := range g.Sample(rng, 100) {
+		seen[x] = true
+	}
+	if !seen[1] || !seen[2] {
+		t.Errorf("Both Gens should have been chosen but only %v were", seen)
+	}
+}
+
+func TestWeightedTreatsNegativeWeightsAsZero(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := Weighted([]WeightedGen[int]{{Gen: Id(1), Weight: -5}, {Gen: Id(2), Weight: 1}})
+	for _, x := range g.Sample(rng, 100) {
+		if x != 2 {
+			t.Fatalf("The Gen with a negative weight should never have been chosen but was")
+		}
+	}
+}
+
+func TestOneOfShrinksWithEveryShrinker(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(OneOf(ChooseInt(0, 100), ChooseInt(1000, 2000)), "Numbers are under 500",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x >= 500 {
+				return false, fmt.Errorf("%v was too big", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[int](t, result)
+	if f := result.(Falsified[int]); f.FailedCase != 1000 {
+		t.Errorf("The counterexample should have shrunk to 1000 but was %v", f.FailedCase)
+	}
+}
+
+func TestElements(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	seen := map[string]bool{}
+	for _, s := range Elements("GET", "PUT", "DELETE").Sample(rng, 100) {
+		seen[s] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Every element should have been produced but only %v were", seen)
+	}
+	if c := Elements("GET", "PUT", "DELETE").Shrinker()("DELETE"); fmt.Sprint(c) != "[GET PUT]" {
+		t.Errorf("DELETE should have shrunk to [GET PUT] but shrank to %v", c)
+	}
+}
+
+func TestRecursiveBoundsDepth(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := Recursive(4, literal, func(sub Gen[expr]) Gen[expr] {
+		return OneOf(literal, Map2(sub, sub, add))
+	})
+	deepest := 0
+	for _, e := range g.Sample(rng, 200) {
+		deepest = max(deepest, e.depth())
+	}
+	if deepest != 4 {
+		t.Errorf("The deepest expression should have had depth 4 but had %v", deepest)
+	}
+}
+
+func TestLazyAllowsSelfReference(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var g Gen[expr]
+	sub := Lazy(func() Gen[expr] { return g })
+	g = Frequency(WeightedGen[expr]{Gen: literal, Weight: 3}, WeightedGen[expr]{Gen: Map2(sub, sub, add), Weight: 1})
+	deepest := 0
+	for _, e := range g.Sample(rng, 200) {
+		deepest = max(deepest, e.depth())
+	}
+	if deepest == 0 {
+		t.Errorf("Some expressions should have been nested")
+	}
+}
+
+func TestCombinatorsPanicForBadArguments(t *testing.T) {
+	for name, f := range map[string]func(){
+		"no Gens":         func() { OneOf[int]() },
+		"negative weight": func() { Frequency(WeightedGen[int]{Gen: Int(), Weight: -1}) },
+		"no values":       func() { Elements[int]() },
+		"negative depth":  func() { Recursive(-1, Int(), func(g Gen[int]) Gen[int] { return g }) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("There should have been a panic for %v", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,96 @@
+package propcheck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// An assertion returns ErrDiscard to discard A test case whose input does not satisfy the precondition of the property. ForAll does not count
+// discarded test cases as successes; it generates replacements instead. See Implies.
+var ErrDiscard = errors.New("test case discarded")
+
+// The number of discarded test cases per test case that ForAll allows when RunParms.MaxDiscardRatio is not set.
+const DefaultMaxDiscardRatio = 5
+
+/*
+*
+Returns A Gen of the values of g that satisfy the predicate p. Like Filter it draws up to MaxFilterTries values, but when none of them
+satisfies p it discards the test case instead of panicking: ForAll counts the test case as discarded and generates A replacement, giving
+up once RunParms.MaxDiscardRatio is exceeded, so A predicate that is too rarely satisfied makes the property give up rather than fail.
+Shrink candidates that do not satisfy p are thrown away.
+
+Discarding works through every combinator, e.g. Map(g.SuchThat(p), f). Outside of ForAll, e.g. in Sample, RunSized or A Fun made by FunOf,
+A discard panics with ErrDiscard, which the caller can recover and recognise with errors.Is.
+*/
+func (g Gen[A]) SuchThat(p func(A) bool) Gen[A] {
+	r := g
+	r.run = func(rng RNG, size Size) (A, RNG) {
+		for x := 0; x < MaxFilterTries; x++ {
+			a, next := g.run(rng, size)
+			if p(a) {
+				return a, next
+			}
+			//Values drawn in sequence from A SimpleRNG are correlated, so each try draws from A Split of the last one.
+			_, rng = next.Split()
+		}
+		panic(ErrDiscard)
+	}
+	r.shrink = filterShrinker(g.shrink, p)
+	return r
+}
+
+// Returns an assertion that discards the test case when cond is false and is otherwise the AssertionAnd of the given assertions.
+// Use it for properties that only make sense for some inputs, e.g. Implies(nonEmpty, assertions...).
+func Implies[A any](cond func(A) bool, assertions ...func(A) (bool, error)) func(A) (bool, error) {
+	and := AssertionAnd(assertions...)
+	return func(a A) (bool, error) {
+		if !cond(a) {
+			return false, ErrDiscard
+		}
+		return and(a)
+	}
+}
+
+// The Result of A property for which ForAll discarded more than RunParms.MaxDiscardRatio test cases per test case before it could
+// run all of them. Nothing was falsified, but the property was not tested enough to pass either, so ExpectSuccess, ExpectFailure
+// and Check all report it as an error.
+type GaveUp[A any] struct {
+	Name      string
+	Seed      RNG
+	Successes int
+	Discards  int
+}
+
+func (w GaveUp[A]) String() string {
+	return fmt.Sprintf("GaveUp{Seed: %v, Name: %v, Successes: %v, Discards: %v}", w.Seed, w.Name, w.Successes, w.Discards)
+}
+
+func (w GaveUp[A]) IsFalsified() bool {
+	return false
+}
+
+func (w GaveUp[A]) inconclusive() {}
+
+func (w GaveUp[A]) report(replay string) string {
+	return fmt.Sprintf("Property %q gave up after %v successful test cases and %v discarded ones.\n  Seed:            %v\n  Replay:          %v",
+		strings.TrimSpace(w.Name), w.Successes, w.Discards, w.Seed, replay)
+}
+
+// Implemented by Results that are neither passed nor falsified.
+type inconclusive interface {
+	inconclusive()
+}
+
+// The maximum number of discards ForAll allows for n.
+func maxDiscards(n RunParms) int {
+	ratio := n.MaxDiscardRatio
+	if ratio <= 0 {
+		ratio = DefaultMaxDiscardRatio
+	}
+	return ratio * n.TestCases
+}
+
+func isDiscard(err error) bool {
+	return errors.Is(err, ErrDiscard)
+}
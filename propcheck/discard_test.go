@@ -0,0 +1,108 @@
+package propcheck
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-test/deep"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuchThatDiscardsTestCases(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	hundreds := func(x int) bool { return x%100 == 0 }
+	prop := ForAll(ChooseInt(0, 1000).SuchThat(hundreds), "Numbers such that they are multiples of 100 must be multiples of 100.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if !hundreds(x) {
+				return false, fmt.Errorf("%v was not a multiple of 100", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	passed, ok := result.(Passed[int])
+	if !ok {
+		t.Fatalf("Property should have passed but was %v", result)
+	}
+	if passed.TestCases != 200 || passed.Discards == 0 {
+		t.Errorf("200 test cases should have run with some discarded but %v ran and %v were discarded", passed.TestCases, passed.Discards)
+	}
+	if diff := deep.Equal(prop.Run(RunParms{TestCases: 200, Rng: rng, Workers: 4}), result); diff != nil {
+		t.Errorf("Workers should not have changed which test cases were discarded: %v", diff)
+	}
+}
+
+func TestSuchThatOutsideForAll(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, x := range ChooseInt(0, 1000).SuchThat(func(x int) bool { return x%2 == 0 }).Sample(rng, 100) {
+		if x%2 != 0 {
+			t.Errorf("%v should have been even", x)
+		}
+	}
+	defer func() {
+		if r, ok := recover().(error); !ok || !errors.Is(r, ErrDiscard) {
+			t.Errorf("A discard outside of ForAll should have panicked with ErrDiscard but panicked with %v", r)
+		}
+	}()
+	Int().SuchThat(func(int) bool { return false }).Sample(rng, 1)
+}
+
+func TestImpliesDiscardsTestCases(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	nonEmpty := func(xs []int) bool { return len(xs) > 0 }
+	prop := ForAll(ChooseArray(0, 2, Int()), "The first element of a non-empty array is in the array.",
+		func(xs []int) []int { return xs },
+		Implies(nonEmpty, func(xs []int) (bool, error) {
+			return xs[0] == xs[0], nil
+		}),
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	passed, ok := result.(Passed[[]int])
+	if !ok {
+		t.Fatalf("Property should have passed but was %v", result)
+	}
+	if passed.TestCases != 100 || passed.Discards == 0 {
+		t.Errorf("100 test cases should have run with some discarded but %v ran and %v were discarded", passed.TestCases, passed.Discards)
+	}
+}
+
+func TestGivesUpAfterTooManyDiscards(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseInt(0, 1000000).SuchThat(func(x int) bool { return x == 0 }), "Zero is rare.",
+		func(x int) int { return x },
+		func(x int) (bool, error) { return true, nil },
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng, MaxDiscardRatio: 2})
+	gaveUp, ok := result.(GaveUp[int])
+	if !ok {
+		t.Fatalf("Property should have given up but was %v", result)
+	}
+	if gaveUp.IsFalsified() || gaveUp.Discards <= 200 || gaveUp.Successes >= 100 {
+		t.Errorf("Property should have given up after more than 200 discards but was %v", gaveUp)
+	}
+	_, report, passed := runCheck("TestX", prop, RunParms{TestCases: 100, Rng: rng, MaxDiscardRatio: 2})
+	if passed || !strings.Contains(report, `Property "Zero is rare." gave up`) {
+		t.Errorf("Check should have failed a property that gave up but reported:\n%v", report)
+	}
+}
+
+func TestDiscardsAreNotCountedAsSuccessesOrShrunkTo(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseInt(0, 1000), "Numbers of at least 10 are less than 50.",
+		func(x int) int { return x },
+		Implies(func(x int) bool { return x >= 10 }, func(x int) (bool, error) {
+			if x >= 50 {
+				return false, fmt.Errorf("%v was too large", x)
+			}
+			return true, nil
+		}),
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[int](t, result)
+	f := result.(Falsified[int])
+	if f.FailedCase != 50 {
+		t.Errorf("The counterexample should have shrunk to 50 without going below 10 but was %v", f.FailedCase)
+	}
+}
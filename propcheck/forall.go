@@ -0,0 +1,162 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The arguments of A test case of ForAll2. String and GoString label each argument with the label of its Gen, see WithLabel, or
+// with its position, e.g. "x: 3, arg2: [1 2]".
+type Args2[A, B any] struct {
+	A     A
+	B     B
+	names [2]string
+}
+
+// The arguments of A test case of ForAll3. See Args2.
+type Args3[A, B, C any] struct {
+	A     A
+	B     B
+	C     C
+	names [3]string
+}
+
+// The arguments of A test case of ForAll4. See Args2.
+type Args4[A, B, C, D any] struct {
+	A     A
+	B     B
+	C     C
+	D     D
+	names [4]string
+}
+
+func (w Args2[A, B]) String() string {
+	return formatArgs(w.names[:], "%v", w.A, w.B)
+}
+
+func (w Args2[A, B]) GoString() string {
+	return formatArgs(w.names[:], "%#v", w.A, w.B)
+}
+
+func (w Args3[A, B, C]) String() string {
+	return formatArgs(w.names[:], "%v", w.A, w.B, w.C)
+}
+
+func (w Args3[A, B, C]) GoString() string {
+	return formatArgs(w.names[:], "%#v", w.A, w.B, w.C)
+}
+
+func (w Args4[A, B, C, D]) String() string {
+	return formatArgs(w.names[:], "%v", w.A, w.B, w.C, w.D)
+}
+
+func (w Args4[A, B, C, D]) GoString() string {
+	return formatArgs(w.names[:], "%#v", w.A, w.B, w.C, w.D)
+}
+
+// Formats each value with verb after its name.
+func formatArgs(names []string, verb string, values ...any) string {
+	var r []string
+	for i, v := range values {
+		r = append(r, fmt.Sprintf("%v: "+verb, names[i], v))
+	}
+	return strings.Join(r, ", ")
+}
+
+// The names of the arguments generated by Gens with the given labels: A label, or "arg" and the position for A Gen without one.
+func argNames(labels ...string) []string {
+	r := make([]string, len(labels))
+	for i, l := range labels {
+		r[i] = l
+		if l == "" {
+			r[i] = fmt.Sprintf("arg%v", i+1)
+		}
+	}
+	return r
+}
+
+// Appends the candidates of s for a, each turned into A test case by with, to r. A nil Shrinker has no candidates.
+func shrinkArg[T, Args any](r []Args, s Shrinker[T], a T, with func(T) Args) []Args {
+	if s == nil {
+		return r
+	}
+	for _, c := range s(a) {
+		r = append(r, with(c))
+	}
+	return r
+}
+
+/*
+*
+ForAll2 is ForAll for A property of two independent arguments, each generated by its own Gen, without combining the Gens with Product
+and the arguments into A Pair. Each assertion takes the arguments separately. A failing test case is shrunk one argument at A time with
+the Shrinker of its Gen, and the Falsified result, of type Falsified[Args2[A, B]], prints each argument of the counterexample labeled
+with the label of its Gen or its position:
+
+	prop := propcheck.ForAll2(propcheck.Int().WithLabel("x"), propcheck.Int().WithLabel("y"), "Addition commutes",
+		func(x, y int) (bool, error) { return propcheck.Equal(x+y, y+x) },
+	)
+
+As with ForAll, only the Gen passed directly is classified and covered, so the Classify and Cover of ga and gb are ignored.
+*/
+func ForAll2[A, B any](ga Gen[A], gb Gen[B], name string, assertions ...func(A, B) (bool, error)) Prop {
+	names := [2]string(argNames(ga.label, gb.label))
+	g := genWithSize(func(rng RNG, size Size) (Args2[A, B], RNG) {
+		a, r := ga.run(rng, size)
+		b, r := gb.run(r, size)
+		return Args2[A, B]{a, b, names}, r
+	}).WithShrinker(func(w Args2[A, B]) []Args2[A, B] {
+		r := shrinkArg(nil, ga.shrink, w.A, func(a A) Args2[A, B] { return Args2[A, B]{a, w.B, w.names} })
+		return shrinkArg(r, gb.shrink, w.B, func(b B) Args2[A, B] { return Args2[A, B]{w.A, b, w.names} })
+	})
+	var as []func(Args2[A, B]) (bool, error)
+	for _, assertion := range assertions {
+		assertion := assertion
+		as = append(as, func(w Args2[A, B]) (bool, error) { return assertion(w.A, w.B) })
+	}
+	return ForAll(g, name, func(w Args2[A, B]) Args2[A, B] { return w }, as...)
+}
+
+// ForAll3 is ForAll2 for A property of three independent arguments. Its Falsified result is of type Falsified[Args3[A, B, C]].
+func ForAll3[A, B, C any](ga Gen[A], gb Gen[B], gc Gen[C], name string, assertions ...func(A, B, C) (bool, error)) Prop {
+	names := [3]string(argNames(ga.label, gb.label, gc.label))
+	g := genWithSize(func(rng RNG, size Size) (Args3[A, B, C], RNG) {
+		a, r := ga.run(rng, size)
+		b, r := gb.run(r, size)
+		c, r := gc.run(r, size)
+		return Args3[A, B, C]{a, b, c, names}, r
+	}).WithShrinker(func(w Args3[A, B, C]) []Args3[A, B, C] {
+		r := shrinkArg(nil, ga.shrink, w.A, func(a A) Args3[A, B, C] { return Args3[A, B, C]{a, w.B, w.C, w.names} })
+		r = shrinkArg(r, gb.shrink, w.B, func(b B) Args3[A, B, C] { return Args3[A, B, C]{w.A, b, w.C, w.names} })
+		return shrinkArg(r, gc.shrink, w.C, func(c C) Args3[A, B, C] { return Args3[A, B, C]{w.A, w.B, c, w.names} })
+	})
+	var as []func(Args3[A, B, C]) (bool, error)
+	for _, assertion := range assertions {
+		assertion := assertion
+		as = append(as, func(w Args3[A, B, C]) (bool, error) { return assertion(w.A, w.B, w.C) })
+	}
+	return ForAll(g, name, func(w Args3[A, B, C]) Args3[A, B, C] { return w }, as...)
+}
+
+// ForAll4 is ForAll2 for A property of four independent arguments. Its Falsified result is of type Falsified[Args4[A, B, C, D]].
+func ForAll4[A, B, C, D any](ga Gen[A], gb Gen[B], gc Gen[C], gd Gen[D], name string, assertions ...func(A, B, C, D) (bool, error)) Prop {
+	names := [4]string(argNames(ga.label, gb.label, gc.label, gd.label))
+	g := genWithSize(func(rng RNG, size Size) (Args4[A, B, C, D], RNG) {
+		a, r := ga.run(rng, size)
+		b, r := gb.run(r, size)
+		c, r := gc.run(r, size)
+		d, r := gd.run(r, size)
+		return Args4[A, B, C, D]{a, b, c, d, names}, r
+	}).WithShrinker(func(w Args4[A, B, C, D]) []Args4[A, B, C, D] {
+		r := shrinkArg(nil, ga.shrink, w.A, func(a A) Args4[A, B, C, D] { return Args4[A, B, C, D]{a, w.B, w.C, w.D, w.names} })
+		r = shrinkArg(r, gb.shrink, w.B, func(b B) Args4[A, B, C, D] { return Args4[A, B, C, D]{w.A, b, w.C, w.D, w.names} })
+		r = shrinkArg(r, gc.shrink, w.C, func(c C) Args4[A, B, C, D] { return Args4[A, B, C, D]{w.A, w.B, c, w.D, w.names} })
+		return shrinkArg(r, gd.shrink, w.D, func(d D) Args4[A, B, C, D] { return Args4[A, B, C, D]{w.A, w.B, w.C, d, w.names} })
+	})
+	var as []func(Args4[A, B, C, D]) (bool, error)
+	for _, assertion := range assertions {
+		assertion := assertion
+		as = append(as, func(w Args4[A, B, C, D]) (bool, error) { return assertion(w.A, w.B, w.C, w.D) })
+	}
+	return ForAll(g, name, func(w Args4[A, B, C, D]) Args4[A, B, C, D] { return w }, as...)
+}
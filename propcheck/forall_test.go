@@ -0,0 +1,56 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestForAll2ShrinksEachArgument(t *testing.T) {
+	prop := ForAll2(ChooseInt(0, 1000).WithLabel("x"), ChooseArray(0, 10, ChooseInt(0, 1000)), "Sums are small",
+		func(x int, ys []int) (bool, error) {
+			sum := x
+			for _, y := range ys {
+				sum += y
+			}
+			return Less(sum, 100)
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: SimpleRNG{Seed: 13634551}})
+	ExpectFailure[Args2[int, []int]](t, result)
+	f := result.(Falsified[Args2[int, []int]])
+	sum := f.FailedCase.A
+	for _, y := range f.FailedCase.B {
+		sum += y
+	}
+	if sum != 100 || len(f.FailedCase.B) > 1 {
+		t.Errorf("The counterexample should have shrunk to a sum of exactly 100 with at most one element but was %v", f.FailedCase)
+	}
+	if s := f.FailedCase.String(); !strings.HasPrefix(s, "x: ") || !strings.Contains(s, ", arg2: [") {
+		t.Errorf("The arguments should have been labeled by Gen label and position but were %v", s)
+	}
+}
+
+func TestForAll3(t *testing.T) {
+	prop := ForAll3(ChooseInt(0, 100), ChooseInt(0, 100), ChooseInt(0, 100), "Addition is associative",
+		func(a, b, c int) (bool, error) { return Equal((a+b)+c, a+(b+c)) },
+	)
+	ExpectSuccess[Args3[int, int, int]](t, prop.Run(RunParms{TestCases: 100, Rng: SimpleRNG{Seed: 13634551}}))
+}
+
+func TestForAll4ReportsLabeledArguments(t *testing.T) {
+	prop := ForAll4(ChooseInt(0, 100).WithLabel("a"), ChooseInt(0, 100).WithLabel("b"), SizedString(), Boolean(), "One of the first two is small",
+		func(a, b int, s string, bl bool) (bool, error) {
+			if a >= 50 && b >= 50 {
+				return false, fmt.Errorf("neither %v nor %v was small", a, b)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 1000, Rng: SimpleRNG{Seed: 13634551}})
+	ExpectFailure[Args4[int, int, string, bool]](t, result)
+	f := result.(Falsified[Args4[int, int, string, bool]])
+	if s := fmt.Sprintf("%#v", f.FailedCase); s != `a: 50, b: 50, arg3: "", arg4: false` {
+		t.Errorf("The counterexample should have shrunk every argument and printed it labeled but was %v", s)
+	}
+}
@@ -0,0 +1,91 @@
+package propcheck
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// A random but deterministic function from A to B generated by FunOf. Call it with Apply, or pass its Apply method where A func(A) B
+// is needed. It remembers the arguments it is called with so that A failing property prints it as A finite table such as {1 -> "x", 7 -> ""}.
+type Fun[A, B any] struct {
+	f     func(A) B
+	calls *funCalls[A, B]
+}
+
+// The distinct arguments A Fun was called with and its results, in the order of the first call with each argument.
+type funCalls[A, B any] struct {
+	sync.Mutex
+	table []Pair[A, B]
+}
+
+func newFun[A, B any](f func(A) B) Fun[A, B] {
+	return Fun[A, B]{f: f, calls: &funCalls[A, B]{}}
+}
+
+// Applies the function to a and remembers the call.
+func (w Fun[A, B]) Apply(a A) B {
+	b := w.f(a)
+	w.calls.Lock()
+	defer w.calls.Unlock()
+	for _, c := range w.calls.table {
+		if reflect.DeepEqual(c.A, a) {
+			return b
+		}
+	}
+	w.calls.table = append(w.calls.table, Pair[A, B]{a, b})
+	return b
+}
+
+// The calls made to the function so far, as pairs of argument and result.
+func (w Fun[A, B]) Calls() []Pair[A, B] {
+	w.calls.Lock()
+	defer w.calls.Unlock()
+	return append([]Pair[A, B]{}, w.calls.table...)
+}
+
+func (w Fun[A, B]) String() string {
+	var entries []string
+	for _, c := range w.Calls() {
+		entries = append(entries, fmt.Sprintf("%#v -> %#v", c.A, c.B))
+	}
+	return fmt.Sprintf("{%v}", strings.Join(entries, ", "))
+}
+
+/*
+*
+Generates random but deterministic functions from A to B: A function perturbs its RNG by its argument with cogen and runs results with
+the perturbed RNG, so it always returns the same result for equal arguments. Use it to test higher-order code, e.g. that arrays.Map
+of the composition of two functions is the composition of arrays.Map of each.
+
+The Gen shrinks A function by shrinking its results for the arguments it was called with, using the Shrinker of results, so the table
+printed for A counterexample shows small results.
+*/
+func FunOf[A, B any](cogen Cogen[A], results Gen[B]) Gen[Fun[A, B]] {
+	g := genWithSize(func(rng RNG, size Size) (Fun[A, B], RNG) {
+		seed, next := rng.Split()
+		return newFun(func(a A) B {
+			b, _ := results.run(cogen(a, seed), size)
+			return b
+		}), next
+	})
+	if results.shrink == nil {
+		return g
+	}
+	return g.WithShrinker(func(w Fun[A, B]) []Fun[A, B] {
+		var r []Fun[A, B]
+		for _, c := range w.Calls() {
+			for _, s := range results.shrink(c.B) {
+				arg, result := c.A, s
+				r = append(r, newFun(func(a A) B {
+					if reflect.DeepEqual(a, arg) {
+						return result
+					}
+					return w.f(a)
+				}))
+			}
+		}
+		return r
+	})
+}
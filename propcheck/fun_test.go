@@ -0,0 +1,60 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFunOfIsDeterministic(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	differ := 0
+	for _, f := range FunOf(CogenInteger[int](), ChooseInt(0, 1000)).Sample(rng, 100) {
+		outputs := map[int]bool{}
+		for x := 0; x < 10; x++ {
+			if f.Apply(x) != f.Apply(x) {
+				t.Errorf("The function should have returned the same result for %v every time", x)
+			}
+			outputs[f.Apply(x)] = true
+		}
+		if len(outputs) > 1 {
+			differ++
+		}
+	}
+	if differ < 90 {
+		t.Errorf("Most functions should have returned different results for different arguments but only %v did", differ)
+	}
+}
+
+func TestFunPrintsItsCalls(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	f, _ := FunOf(CogenString(), Id(7)).Run(rng)
+	f.Apply("a")
+	f.Apply("b")
+	f.Apply("a")
+	if s := f.String(); s != `{"a" -> 7, "b" -> 7}` {
+		t.Errorf(`The function should have printed as {"a" -> 7, "b" -> 7} but printed as %v`, s)
+	}
+}
+
+func TestFunOfShrinksResults(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(FunOf(CogenInteger[int](), ChooseInt(0, 100)), "Functions return numbers under 50 for 0 to 4",
+		func(f Fun[int, int]) Fun[int, int] { return f },
+		func(f Fun[int, int]) (bool, error) {
+			for x := 0; x < 5; x++ {
+				if y := f.Apply(x); y >= 50 {
+					return false, fmt.Errorf("f(%v) was %v", x, y)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[Fun[int, int]](t, result)
+	f := result.(Falsified[Fun[int, int]])
+	if s := f.FailedCase.String(); !strings.HasSuffix(s, " -> 50}") || strings.Count(s, "->") > 5 {
+		t.Errorf("The last call of the counterexample should have shrunk to 50 but was %v", s)
+	}
+}
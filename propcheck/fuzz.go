@@ -0,0 +1,150 @@
+package propcheck
+
+import (
+	"fmt"
+	"math/bits"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The number of seed corpus entries Fuzz adds, so that A plain "go test" runs the property on that many inputs.
+const FuzzSeeds = DefaultTestCases
+
+// The length in bytes of each seed corpus entry that Fuzz adds.
+const FuzzSeedLength = 256
+
+/*
+*
+An RNG that reads its values from A fixed slice of bytes, such as the input of A fuzz test, and produces zeros once the bytes run out.
+Small changes to the bytes make small changes to the generated values, which lets A coverage-guided fuzzer steer generators, and running
+out of bytes makes generators produce their smallest values, such as empty arrays. Intn only reads as many bytes as it needs.
+*/
+type BytesRNG struct {
+	Data   []byte
+	Offset int //The index of the next byte to read.
+}
+
+func (w BytesRNG) String() string {
+	return fmt.Sprintf("BytesRNG{%v bytes, Offset: %v}", len(w.Data), w.Offset)
+}
+
+// Reads n bytes as A little endian number.
+func (w BytesRNG) read(n int) (uint64, BytesRNG) {
+	var u uint64
+	for x := 0; x < n; x++ {
+		if w.Offset < len(w.Data) {
+			u = u | uint64(w.Data[w.Offset])<<(8*x)
+		}
+		w.Offset++
+	}
+	return u, w
+}
+
+func (w BytesRNG) Uint64() (uint64, RNG) {
+	return w.read(8)
+}
+
+func (w BytesRNG) NextInt() (int, RNG) {
+	u, r := w.read(8)
+	return int(u), r
+}
+
+// Reads the fewest bytes that can hold A number below n and takes it modulo n.
+func (w BytesRNG) Intn(n int) (int, RNG) {
+	if n <= 0 {
+		panic(fmt.Sprintf("Intn requires n > 0 but n was %v", n))
+	}
+	u, r := w.read((bits.Len64(uint64(n-1)) + 7) / 8)
+	return int(u % uint64(n)), r
+}
+
+// The first RNG reads on from the bytes and the second is A SplitMix64 seeded from the next 8 bytes.
+func (w BytesRNG) Split() (RNG, RNG) {
+	u, r := w.read(8)
+	return r, SplitMix64{State: u}
+}
+
+/*
+*
+Fuzz runs A ForAll-style property as A native Go fuzz test, so that "go test -fuzz" steers the generator toward new code paths. Each fuzz
+input is turned into A BytesRNG that runs ge once with DefaultMaxSize, and the property is checked for the value it generates. A failing
+value is shrunk and reported like A falsified Check, and A discarded value skips the input. The coverage requirements of ge are ignored
+because there is only one value per input.
+
+Without -fuzz, "go test" runs the property for the inputs in testdata/fuzz and FuzzSeeds generated seed inputs. Use FuzzValue or
+ReadCorpusEntry to see the value generated from A corpus entry.
+
+	func FuzzMakeSet(f *testing.F) {
+		propcheck.Fuzz(f, propcheck.SizedArray(propcheck.Int()), "Sets have no duplicates", toSet, noDuplicates)
+	}
+*/
+func Fuzz[A, B any](f *testing.F, ge Gen[A], name string, transform func(A) B, assertions ...func(B) (bool, error)) {
+	f.Helper()
+	g := ge
+	g.cover = nil
+	prop := ForAll(g, name, transform, assertions...)
+	var rng RNG = SplitMix64{}
+	for x := 0; x < FuzzSeeds; x++ {
+		seed := make([]byte, FuzzSeedLength)
+		for i := range seed {
+			var u uint64
+			u, rng = rng.Uint64()
+			seed[i] = byte(u)
+		}
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		report, ok, skip := fuzzCheck(t.Name(), prop, data)
+		if skip {
+			t.Skip(report)
+		}
+		if !ok {
+			t.Fatal(report)
+		}
+	})
+}
+
+// Checks the property for the value generated from data, returning A report on A failure and whether the input was discarded.
+func fuzzCheck(testName string, prop Prop, data []byte) (report string, ok, skip bool) {
+	result := prop.Run(RunParms{TestCases: 1, Rng: BytesRNG{Data: data}})
+	if _, gaveUp := result.(inconclusive); gaveUp {
+		return "The generated value was discarded", true, true
+	}
+	if !result.IsFalsified() {
+		return "", true, false
+	}
+	if r, isReporter := result.(reporter); isReporter {
+		return r.report(fmt.Sprintf("go test -count=1 -run '%v' .", runPattern(testName))), false, false
+	}
+	return fmt.Sprintf("Property %q was falsified: %v", prop.Name, result), false, false
+}
+
+// The value Fuzz generates from the given fuzz input with the given Gen, before any shrinking.
+func FuzzValue[A any](ge Gen[A], data []byte) A {
+	a, _ := ge.RunSized(BytesRNG{Data: data}, DefaultMaxSize)
+	return a
+}
+
+// Reads the input of A fuzz test from A corpus file such as testdata/fuzz/FuzzMakeSet/582528ddfad69eb5, for use with FuzzValue.
+// It supports the files written for Fuzz, whose only argument is A []byte.
+func ReadCorpusEntry(path string) ([]byte, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 2 || lines[0] != "go test fuzz v1" {
+		return nil, fmt.Errorf("%v is not a corpus file with a single []byte", path)
+	}
+	arg := strings.TrimSpace(lines[1])
+	if !strings.HasPrefix(arg, "[]byte(") || !strings.HasSuffix(arg, ")") {
+		return nil, fmt.Errorf("%v is not a corpus file with a single []byte", path)
+	}
+	s, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(arg, "[]byte("), ")"))
+	if err != nil {
+		return nil, fmt.Errorf("%v has a malformed []byte: %w", path, err)
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,90 @@
+package propcheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBytesRNGReadsBytesThenZeros(t *testing.T) {
+	var rng RNG = BytesRNG{Data: []byte{7, 1, 2}}
+	i, rng := rng.Intn(10)
+	if i != 7 {
+		t.Errorf("Intn(10) should have read the first byte, 7, but was %v", i)
+	}
+	u, rng := rng.Uint64()
+	if u != 0x0201 {
+		t.Errorf("Uint64 should have read the remaining bytes little endian as 0x201 but was %#x", u)
+	}
+	if j, _ := rng.Intn(1000); j != 0 {
+		t.Errorf("An exhausted BytesRNG should have produced 0 but produced %v", j)
+	}
+	if xs := FuzzValue(SizedArray(Int()), nil); len(xs) != 0 {
+		t.Errorf("No bytes should have generated an empty array but generated %v", xs)
+	}
+}
+
+func TestFuzzCheckReportsShrunkFailures(t *testing.T) {
+	prop := ForAll(SizedArray(ChooseInt(0, 1000)), "Arrays have fewer than 3 elements",
+		func(xs []int) []int { return xs },
+		func(xs []int) (bool, error) {
+			if len(xs) >= 3 {
+				return false, fmt.Errorf("%v had %v elements", xs, len(xs))
+			}
+			return true, nil
+		},
+	)
+	data := []byte{10, 0, 0, 0, 0, 0, 0, 0, 200, 1, 0, 0, 0, 0, 0, 0, 5, 2}
+	if xs := FuzzValue(SizedArray(ChooseInt(0, 1000)), data); len(xs) < 3 {
+		t.Fatalf("The input should have generated at least 3 elements but generated %v", xs)
+	}
+	report, ok, skip := fuzzCheck("FuzzArrays/abc", prop, data)
+	if ok || skip {
+		t.Fatalf("The property should have failed")
+	}
+	if !strings.Contains(report, "Counterexample:  [0 0 0]") || !strings.Contains(report, "-run '^FuzzArrays$/^abc$'") {
+		t.Errorf("The report should have had a shrunk counterexample and a command to re-run the input but was %v", report)
+	}
+	discarding := ForAll(Int().SuchThat(func(int) bool { return false }), "Nothing", func(x int) int { return x }, alwaysTrue)
+	if _, ok, skip := fuzzCheck("FuzzNothing", discarding, data); !ok || !skip {
+		t.Errorf("A discarded input should have been skipped")
+	}
+}
+
+func TestReadCorpusEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "582528ddfad69eb5")
+	if err := os.WriteFile(path, []byte("go test fuzz v1\n[]byte(\"\\x03a\\x00\")\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ReadCorpusEntry(path)
+	if err != nil || string(data) != "\x03a\x00" {
+		t.Errorf("The entry should have been \"\\x03a\\x00\" but was %q, %v", data, err)
+	}
+	if err := os.WriteFile(path, []byte("go test fuzz v1\nint(3)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCorpusEntry(path); err == nil {
+		t.Errorf("An entry that is not a []byte should have been an error")
+	}
+}
+
+func FuzzReverseTwiceIsIdentity(f *testing.F) {
+	reverse := func(xs []int) []int {
+		r := make([]int, len(xs))
+		for i, x := range xs {
+			r[len(xs)-1-i] = x
+		}
+		return r
+	}
+	Fuzz(f, SizedArray(Int()), "Reversing twice gives back the array",
+		func(xs []int) Pair[[]int, []int] { return Pair[[]int, []int]{xs, reverse(reverse(xs))} },
+		func(p Pair[[]int, []int]) (bool, error) {
+			if fmt.Sprint(p.A) != fmt.Sprint(p.B) {
+				return false, fmt.Errorf("%v reversed twice was %v", p.A, p.B)
+			}
+			return true, nil
+		},
+	)
+}
@@ -2,68 +2,48 @@ package propcheck
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
 
-type SimpleRNG struct {
-	Seed int
-}
-
-func (w SimpleRNG) String() string {
-	return fmt.Sprintf("SimpleRMG{Seed: %v}", w.Seed)
-}
-
-func NextInt(r SimpleRNG) (int, SimpleRNG) {
-	newSeed := (r.Seed*0x5DEECE66D + 0xB) & 0xFFFFFFFFFFFF
-	nextRNG := SimpleRNG{newSeed}
-	n := newSeed >> 16
-	return n, nextRNG
-}
-
-// All the subsequent functions return A function which takes A SimpleRNG and returns an (A(or B or C), SimpleRNG) pair.
+// All the subsequent functions return A Gen which wraps A function that takes an RNG and returns an (A(or B or C), RNG) pair.
 
 // Generate A random Int.
-func Int() func(SimpleRNG) (int, SimpleRNG) {
-	return func(r SimpleRNG) (int, SimpleRNG) {
-		return NextInt(r)
-	}
+func Int() Gen[int] {
+	return NewGen(func(r RNG) (int, RNG) {
+		return r.NextInt()
+	}).WithShrinker(ShrinkInt)
 }
 
 type WeightedGen[A any] struct {
-	Gen    func(rng SimpleRNG) (A, SimpleRNG)
+	Gen    Gen[A]
 	Weight int
 }
 
-// Generates A random value from A set of generators in proportion to an individual generator's weight in the list.
-func Weighted[A any](wgen []WeightedGen[A]) func(SimpleRNG) (A, SimpleRNG) {
-	return func(rng SimpleRNG) (A, SimpleRNG) {
-		var r []func(SimpleRNG) (A, SimpleRNG)
-		for _, p := range wgen {
-			for i := 0; i < p.Weight; i++ {
-				r = append(r, p.Gen)
-			}
-		}
-		a := ChooseInt(0, len(r))
-		b, _ := a(rng)
-		d := b
-		g, rng2 := r[d](rng)
-		return g, rng2
+// Generates A random value from A set of generators in proportion to an individual generator's weight in the list. See Frequency.
+// Unlike Frequency, Weighted treats A negative weight as zero.
+func Weighted[A any](wgen []WeightedGen[A]) Gen[A] {
+	var gens []WeightedGen[A]
+	for _, w := range wgen {
+		gens = append(gens, WeightedGen[A]{w.Gen, max(w.Weight, 0)})
 	}
+	return Frequency(gens...)
 }
 
 // Generates A non-negative integer
-var NonNegativeInt = func(rng SimpleRNG) (int, SimpleRNG) {
-	i, r := NextInt(rng)
+var NonNegativeInt = NewGen(func(rng RNG) (int, RNG) {
+	i, r := rng.NextInt()
 	if i < 0 {
 		return -(i + 1), r
 	} else {
 		return i, r
 	}
-}
+}).WithShrinker(ShrinkInt)
 
-// Generates A float64 floating point number
-var Float = func() func(SimpleRNG) (float64, SimpleRNG) {
+// Generates A float64 floating point number of the form 1/n for A non-negative n, or zero.
+// Use Float64 for the whole range of float64, including the special values, and ChooseFloat for A range.
+var Float = func() Gen[float64] {
 	fa := func(a int) float64 {
 		aa := a
 		aaa := float64(aa)
@@ -76,7 +56,7 @@ var Float = func() func(SimpleRNG) (float64, SimpleRNG) {
 	return Map(NonNegativeInt, fa)
 }
 
-var EmptyString = func() func(SimpleRNG) (string, SimpleRNG) {
+var EmptyString = func() Gen[string] {
 	return Id("")
 }
 
@@ -84,14 +64,16 @@ var EmptyString = func() func(SimpleRNG) (string, SimpleRNG) {
 // You must understand these things about Unicode and character sets. The jist is that A Unicode codepoint('\u04E00' for example) can result in A string of 1 to 4 characters
 // depending on the character set.
 
+// String only uses the characters 0-9, a-z and A-Z. See UnicodeString, StringOf and RuneFrom for real-world text.
 // Be careful about specifying the stringMaxSize because if you make it too large you will probably never end up with an empty string. A rule of
 // thumb is to make the stringMaxSize 1/3 of the number of test cases you are running.
-func String(unicodeMaxSize int) func(SimpleRNG) (string, SimpleRNG) {
+// SizedString avoids the problem altogether by growing the string length with the Size of each test case.
+func String(unicodeMaxSize int) Gen[string] {
 	f := func(numOfCharactersInSet int, startingRune rune) []string {
 		var unicodeStrings []string
 		var currentUnicodeString string
 		var currentRune = startingRune
-		for x := 0; x <= numOfCharactersInSet; x++ {
+		for x := 0; x < numOfCharactersInSet; x++ {
 			currentUnicodeString = fmt.Sprintf("%v", string(currentRune))
 			unicodeStrings = append(unicodeStrings, currentUnicodeString)
 			currentRune = currentRune + 0x01
@@ -112,23 +94,24 @@ func String(unicodeMaxSize int) func(SimpleRNG) (string, SimpleRNG) {
 	start := 0
 	stopInclusive := len(bigUnicodeList)
 
-	return func(rng SimpleRNG) (string, SimpleRNG) {
-		var i int                                            //The index into the big array of Unicode codepoints.
-		var lr = rng                                         //The ever-changing random number generator inside the loop below.
-		var res []string                                     //The growing list of unicode codepoints for making A single string at the end.
-		var randomMaxSize int                                //The max size of this string measured by the number of unicode code points, not necessarily the size of the resulting string.
-		randomMaxSize, lr = ChooseInt(0, unicodeMaxSize)(lr) //Randomly choose A value for the number of Unicode code points.
+	g := NewGen(func(rng RNG) (string, RNG) {
+		var i int                                                //The index into the big array of Unicode codepoints.
+		var lr = rng                                             //The ever-changing random number generator inside the loop below.
+		var res []string                                         //The growing list of unicode codepoints for making A single string at the end.
+		var randomMaxSize int                                    //The max size of this string measured by the number of unicode code points, not necessarily the size of the resulting string.
+		randomMaxSize, lr = ChooseInt(0, unicodeMaxSize).Run(lr) //Randomly choose A value for the number of Unicode code points.
 		for x := 0; x < randomMaxSize; x++ {
-			_, lr = NextInt(lr)
-			i, lr = ChooseInt(start, stopInclusive)(lr)
+			_, lr = lr.NextInt()
+			i, lr = ChooseInt(start, stopInclusive).Run(lr)
 			res = append(res, bigUnicodeList[i])
 		}
 		return strings.Join(res, ""), lr
-	}
+	})
+	return g.WithShrinker(ShrinkString)
 }
 
 // Generates a random date (stopExclusive - start) days from or preceding 1999-12-31.
-func ChooseDate(start int, stopExclusive int) func(SimpleRNG) (time.Time, SimpleRNG) {
+func ChooseDate(start int, stopExclusive int) Gen[time.Time] {
 	g := func(days int, past bool) time.Time {
 		ninetynine := "1999-12-31"
 		current, _ := time.Parse("2006-01-02", ninetynine)
@@ -141,32 +124,52 @@ func ChooseDate(start int, stopExclusive int) func(SimpleRNG) (time.Time, Simple
 	return Map2(ChooseInt(start, stopExclusive), Boolean(), g)
 }
 
-// Generates an integer between start and stop exclusive
-func ChooseInt(start int, stopExclusive int) func(SimpleRNG) (int, SimpleRNG) {
-	fa := func(a int) int {
+// Generates an integer between start and stop exclusive.
+// The Gen shrinks toward the value in the range that is closest to zero.
+func ChooseInt(start int, stopExclusive int) Gen[int] {
+	g := NewGen(func(rng RNG) (int, RNG) {
 		var divisor = stopExclusive - start
 		if divisor <= 0 {
 			divisor = 1
 		}
-		aa := a //.(int)
-		r := start + aa%(divisor)
+		i, r := rng.Intn(divisor)
+		return start + i, r
+	})
+	target := start
+	if start < 0 && stopExclusive > 0 {
+		target = 0
+	}
+	shrink := func(x int) []int {
+		var r []int
+		for _, c := range ShrinkInt(x - target) {
+			if c+target >= start && c+target < stopExclusive {
+				r = append(r, c+target)
+			}
+		}
 		return r
 	}
-	return Map(NonNegativeInt, fa)
+	return g.WithShrinker(shrink)
 }
 
 // Generates A random boolean
-func Boolean() func(SimpleRNG) (bool, SimpleRNG) {
-	fa := func(a int) bool {
-		aa := a
-		return aa%2 == 0
+func Boolean() Gen[bool] {
+	g := NewGen(func(rng RNG) (bool, RNG) {
+		i, r := rng.Intn(2)
+		return i == 0, r
+	})
+	shrink := func(b bool) []bool {
+		if b {
+			return []bool{false}
+		}
+		return nil
 	}
-	return Map(NonNegativeInt, fa)
+	return g.WithShrinker(shrink)
 }
 
-// Generates an array of N elements from the given generator
-func ArrayOfN[T any](n int, g func(SimpleRNG) (T, SimpleRNG)) func(SimpleRNG) ([]T, SimpleRNG) {
-	var s []func(SimpleRNG) (T, SimpleRNG)
+// Generates an array of N elements from the given generator.
+// The Gen shrinks elements in place and never changes the length of the array.
+func ArrayOfN[T any](n int, g Gen[T]) Gen[[]T] {
+	var s []Gen[T]
 	for x := 0; x < n; x++ {
 		s = append(s, g)
 	}
@@ -174,14 +177,76 @@ func ArrayOfN[T any](n int, g func(SimpleRNG) (T, SimpleRNG)) func(SimpleRNG) ([
 	return u
 }
 
-// Generates an array with A size in the indicated range using the given Gen
-func ChooseArray[T any](start, stopInclusive int, kind func(SimpleRNG) (T, SimpleRNG)) func(SimpleRNG) ([]T, SimpleRNG) {
-	return func(rng SimpleRNG) ([]T, SimpleRNG) {
+// Generates an array with A size in the indicated range using the given Gen.
+// The Gen shrinks by removing elements, never below the low range, and by shrinking elements with the Shrinker of kind.
+func ChooseArray[T any](start, stopInclusive int, kind Gen[T]) Gen[[]T] {
+	g := genWithSize(func(rng RNG, size Size) ([]T, RNG) {
 		if start < 0 || start > stopInclusive {
 			panic(fmt.Sprintf("Low range[%v] was < 0 or exceeded the high range[%v]", start, stopInclusive))
 		}
-		i, _ := ChooseInt(start, stopInclusive)(rng)
-		r, rng2 := ArrayOfN(i, kind)(rng)
+		i, _ := ChooseInt(start, stopInclusive).Run(rng)
+		r, rng2 := ArrayOfN(i, kind).run(rng, size)
 		return r, rng2
+	})
+	shrink := func(xs []T) [][]T {
+		var r [][]T
+		for _, c := range ShrinkArray(kind.shrink)(xs) {
+			if len(c) >= start {
+				r = append(r, c)
+			}
+		}
+		return r
+	}
+	return g.WithShrinker(shrink)
+}
+
+// Generates A map of between zero and Size entries using the given Gens of keys and values. See ChooseMap.
+func MapOf[K comparable, V any](keys Gen[K], values Gen[V]) Gen[map[K]V] {
+	return Sized(func(size Size) Gen[map[K]V] {
+		return ChooseMap(0, size, keys, values)
+	}).WithShrinker(shrinkMap(keys.shrink, values.shrink))
+}
+
+// Generates A map with between start and stopInclusive entries using the given Gens of keys and values.
+// Generated keys may collide, so A map can have fewer than start entries when the keys Gen has too few distinct values(i.e. bool keys).
+// The Gen shrinks by removing entries, never below start, and by shrinking keys and values with their Shrinkers.
+func ChooseMap[K comparable, V any](start, stopInclusive int, keys Gen[K], values Gen[V]) Gen[map[K]V] {
+	if start < 0 || start > stopInclusive {
+		panic(fmt.Sprintf("Low range[%v] was < 0 or exceeded the high range[%v]", start, stopInclusive))
+	}
+	g := genWithSize(func(rng RNG, size Size) (map[K]V, RNG) {
+		n, r := rng.Intn(stopInclusive - start + 1)
+		n = n + start
+		m := make(map[K]V, n)
+		for x := 0; x < n*MaxFilterTries && len(m) < n; x++ {
+			var k K
+			var v V
+			k, r = keys.run(r, size)
+			v, r = values.run(r, size)
+			m[k] = v
+		}
+		return m, r
+	})
+	return g.WithShrinker(filterShrinker(shrinkMap(keys.shrink, values.shrink), func(m map[K]V) bool { return len(m) >= start }))
+}
+
+// Shrinks A map as an array of its entries ordered by key, so that shrinking is repeatable. A key that shrinks onto another key
+// replaces that entry.
+func shrinkMap[K comparable, V any](keys Shrinker[K], values Shrinker[V]) Shrinker[map[K]V] {
+	toMap := func(ps []Pair[K, V]) map[K]V {
+		m := make(map[K]V, len(ps))
+		for _, p := range ps {
+			m[p.A] = p.B
+		}
+		return m
+	}
+	toPairs := func(m map[K]V) []Pair[K, V] {
+		ps := make([]Pair[K, V], 0, len(m))
+		for k, v := range m {
+			ps = append(ps, Pair[K, V]{k, v})
+		}
+		sort.Slice(ps, func(i, j int) bool { return fmt.Sprint(ps[i].A) < fmt.Sprint(ps[j].A) })
+		return ps
 	}
+	return ShrinkConvert(ShrinkArray(ShrinkPair(keys, values)), toMap, toPairs)
 }
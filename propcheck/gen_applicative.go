@@ -11,43 +11,43 @@ func (w Pair[A, B]) String() string {
 	return fmt.Sprintf("Pair{A: %v \n, B: %v\n}\n", w.A, w.B)
 }
 
-func Product[A, B any](fa func(SimpleRNG) (A, SimpleRNG), fb func(SimpleRNG) (B, SimpleRNG)) func(SimpleRNG) (Pair[A, B], SimpleRNG) {
+func Product[A, B any](fa Gen[A], fb Gen[B]) Gen[Pair[A, B]] {
 	f := func(a A, b B) Pair[A, B] {
 		return Pair[A, B]{a, b}
 	}
 	g := Map2(fa, fb, f)
-	return g
+	return g.WithShrinker(ShrinkPair(fa.shrink, fb.shrink))
 }
 
 // MapN are the functions that make this an Applicative Functor. These functions allow you to compose generators without the context-sensitivity that you get with FlatMap.
 // A good example of this is validation where you don't want the computation to stop because A Flatmap in the chain fails.
-func pMap2[A, B, C any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), f func(a A, b B) C) func(rng SimpleRNG) (C, SimpleRNG) {
-	return func(rng SimpleRNG) (C, SimpleRNG) {
-		a, r1 := ra(rng)
-		b, r2 := rb(r1)
+func pMap2[A, B, C any](ra Gen[A], rb Gen[B], f func(a A, b B) C) Gen[C] {
+	return genWithSize(func(rng RNG, size Size) (C, RNG) {
+		a, r1 := ra.run(rng, size)
+		b, r2 := rb.run(r1, size)
 		c := f(a, b)
 		return c, r2
-	}
+	})
 }
-func Map2[A, B, C any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), f func(a A, b B) C) func(rng SimpleRNG) (C, SimpleRNG) {
+func Map2[A, B, C any](ra Gen[A], rb Gen[B], f func(a A, b B) C) Gen[C] {
 	return pMap2(ra, rb, f)
 }
 
-func Map3[A, B, C, D any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG),
-	rc func(SimpleRNG) (C, SimpleRNG), f func(a A, b B, c C) D) func(SimpleRNG) (D, SimpleRNG) {
-	return func(rng SimpleRNG) (D, SimpleRNG) {
+func Map3[A, B, C, D any](ra Gen[A], rb Gen[B],
+	rc Gen[C], f func(a A, b B, c C) D) Gen[D] {
+	return genWithSize(func(rng RNG, size Size) (D, RNG) {
 		fab := Product[A, B](ra, rb)
 		fg := func(abd Pair[A, B], c C) D {
 			return f(abd.A, abd.B, c)
 		}
 		g := Map2(fab, rc, fg)
-		return g(rng)
-	}
+		return g.run(rng, size)
+	})
 }
 
-func Map4[A, B, C, D, E any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), rc func(SimpleRNG) (C, SimpleRNG),
-	rd func(SimpleRNG) (D, SimpleRNG), f func(a A, b B, c C, d D) E) func(SimpleRNG) (E, SimpleRNG) {
-	return func(rng SimpleRNG) (E, SimpleRNG) {
+func Map4[A, B, C, D, E any](ra Gen[A], rb Gen[B], rc Gen[C],
+	rd Gen[D], f func(a A, b B, c C, d D) E) Gen[E] {
+	return genWithSize(func(rng RNG, size Size) (E, RNG) {
 
 		fab := Product(ra, rb)
 		fcd := Product(rc, rd)
@@ -59,14 +59,14 @@ func Map4[A, B, C, D, E any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRN
 		}
 
 		g := Map2(fab, fcd, fg)
-		return g(rng)
-	}
+		return g.run(rng, size)
+	})
 }
 
-func Map8[A, B, C, D, E, F, G, H, I any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), rc func(SimpleRNG) (C, SimpleRNG),
-	rd func(SimpleRNG) (D, SimpleRNG), re func(SimpleRNG) (E, SimpleRNG), rf func(SimpleRNG) (F, SimpleRNG),
-	rg func(SimpleRNG) (G, SimpleRNG), rh func(SimpleRNG) (H, SimpleRNG), f func(a A, b B, c C, d D, e E, f F, g G, h H) I) func(SimpleRNG) (I, SimpleRNG) {
-	return func(rng SimpleRNG) (I, SimpleRNG) {
+func Map8[A, B, C, D, E, F, G, H, I any](ra Gen[A], rb Gen[B], rc Gen[C],
+	rd Gen[D], re Gen[E], rf Gen[F],
+	rg Gen[G], rh Gen[H], f func(a A, b B, c C, d D, e E, f F, g G, h H) I) Gen[I] {
+	return genWithSize(func(rng RNG, size Size) (I, RNG) {
 
 		fab := Product(ra, rb)
 		fcd := Product(rc, rd)
@@ -79,16 +79,16 @@ func Map8[A, B, C, D, E, F, G, H, I any](ra func(SimpleRNG) (A, SimpleRNG), rb f
 
 		g := Map4(fab, fcd, fef, fgh, fg)
 
-		return g(rng)
-	}
+		return g.run(rng, size)
+	})
 }
 
-func Map16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), rc func(SimpleRNG) (C, SimpleRNG),
-	rd func(SimpleRNG) (D, SimpleRNG), re func(SimpleRNG) (E, SimpleRNG), rf func(SimpleRNG) (F, SimpleRNG),
-	rg func(SimpleRNG) (G, SimpleRNG), rh func(SimpleRNG) (H, SimpleRNG), ri func(SimpleRNG) (I, SimpleRNG), rj func(SimpleRNG) (J, SimpleRNG), rk func(SimpleRNG) (K, SimpleRNG), rl func(SimpleRNG) (L, SimpleRNG),
-	rm func(SimpleRNG) (M, SimpleRNG), rn func(SimpleRNG) (N, SimpleRNG), ro func(SimpleRNG) (O, SimpleRNG), rp func(SimpleRNG) (P, SimpleRNG),
-	f func(a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, p P) Q) func(SimpleRNG) (Q, SimpleRNG) {
-	return func(rng SimpleRNG) (Q, SimpleRNG) {
+func Map16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q any](ra Gen[A], rb Gen[B], rc Gen[C],
+	rd Gen[D], re Gen[E], rf Gen[F],
+	rg Gen[G], rh Gen[H], ri Gen[I], rj Gen[J], rk Gen[K], rl Gen[L],
+	rm Gen[M], rn Gen[N], ro Gen[O], rp Gen[P],
+	f func(a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, p P) Q) Gen[Q] {
+	return genWithSize(func(rng RNG, size Size) (Q, RNG) {
 
 		fab := Product(ra, rb)
 		fcd := Product(rc, rd)
@@ -105,19 +105,19 @@ func Map16[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q any](ra func(Simple
 
 		g := Map8(fab, fcd, fef, fgh, fij, fkl, fmn, fop, fg)
 
-		return g(rng)
-	}
+		return g.run(rng, size)
+	})
 }
 
-func Map32[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, T, U, V, W, X, AA, BB, CC, DD, EE, FF, GG, HH, II any](ra func(SimpleRNG) (A, SimpleRNG), rb func(SimpleRNG) (B, SimpleRNG), rc func(SimpleRNG) (C, SimpleRNG),
-	rd func(SimpleRNG) (D, SimpleRNG), re func(SimpleRNG) (E, SimpleRNG), rf func(SimpleRNG) (F, SimpleRNG),
-	rg func(SimpleRNG) (G, SimpleRNG), rh func(SimpleRNG) (H, SimpleRNG), ri func(SimpleRNG) (I, SimpleRNG), rj func(SimpleRNG) (J, SimpleRNG), rk func(SimpleRNG) (K, SimpleRNG), rl func(SimpleRNG) (L, SimpleRNG),
-	rm func(SimpleRNG) (M, SimpleRNG), rn func(SimpleRNG) (N, SimpleRNG), ro func(SimpleRNG) (O, SimpleRNG), rp func(SimpleRNG) (P, SimpleRNG), rq func(SimpleRNG) (Q, SimpleRNG), rr func(SimpleRNG) (R, SimpleRNG),
-	rs func(SimpleRNG) (S, SimpleRNG), rt func(SimpleRNG) (T, SimpleRNG), ru func(SimpleRNG) (U, SimpleRNG), rv func(SimpleRNG) (V, SimpleRNG), rw func(SimpleRNG) (W, SimpleRNG), rx func(SimpleRNG) (X, SimpleRNG),
-	raa func(SimpleRNG) (AA, SimpleRNG), rbb func(SimpleRNG) (BB, SimpleRNG), rcc func(SimpleRNG) (CC, SimpleRNG), rdd func(SimpleRNG) (DD, SimpleRNG), ree func(SimpleRNG) (EE, SimpleRNG), rff func(SimpleRNG) (FF, SimpleRNG),
-	rgg func(SimpleRNG) (GG, SimpleRNG), rhh func(SimpleRNG) (HH, SimpleRNG),
-	f func(a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, p P, q Q, r R, s S, t T, u U, v V, w W, x X, aa AA, bb BB, cc CC, dd DD, ee EE, ff FF, gg GG, hh HH) II) func(SimpleRNG) (II, SimpleRNG) {
-	return func(rng SimpleRNG) (II, SimpleRNG) {
+func Map32[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, T, U, V, W, X, AA, BB, CC, DD, EE, FF, GG, HH, II any](ra Gen[A], rb Gen[B], rc Gen[C],
+	rd Gen[D], re Gen[E], rf Gen[F],
+	rg Gen[G], rh Gen[H], ri Gen[I], rj Gen[J], rk Gen[K], rl Gen[L],
+	rm Gen[M], rn Gen[N], ro Gen[O], rp Gen[P], rq Gen[Q], rr Gen[R],
+	rs Gen[S], rt Gen[T], ru Gen[U], rv Gen[V], rw Gen[W], rx Gen[X],
+	raa Gen[AA], rbb Gen[BB], rcc Gen[CC], rdd Gen[DD], ree Gen[EE], rff Gen[FF],
+	rgg Gen[GG], rhh Gen[HH],
+	f func(a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, k K, l L, m M, n N, o O, p P, q Q, r R, s S, t T, u U, v V, w W, x X, aa AA, bb BB, cc CC, dd DD, ee EE, ff FF, gg GG, hh HH) II) Gen[II] {
+	return genWithSize(func(rng RNG, size Size) (II, RNG) {
 		fab := Product(ra, rb)
 		fcd := Product(rc, rd)
 		fef := Product(re, rf)
@@ -141,6 +141,6 @@ func Map32[A, B, C, D, E, F, G, H, I, J, K, L, M, N, O, P, Q, R, S, T, U, V, W,
 				st.A, st.B, uv.A, uv.B, wx.A, wx.B, aabb.A, aabb.B, ccdd.A, ccdd.B, eeff.A, eeff.B, gghh.A, gghh.B)
 		}
 		g := Map16(fab, fcd, fef, fgh, fij, fkl, fmn, fop, fqr, fst, fuv, fxy, faabb, fccdd, feeff, fgghh, fg)
-		return g(rng)
-	}
+		return g.run(rng, size)
+	})
 }
@@ -13,7 +13,7 @@ func TestMap2WithInt(t *testing.T) {
 		return a + b
 	}
 	res := Map2(ra, rb, f)
-	actual, _ := res(rng)
+	actual, _ := res.Run(rng)
 	if actual != 25 {
 		t.Errorf("Map2 should have summed the A and B inside the SimpleRNG: %v but resulted in %v \n", 12+13, actual)
 	}
@@ -30,7 +30,7 @@ func TestMap2ChooseInt(t *testing.T) {
 	}
 
 	r3 := Map2(r1, r2, f)
-	a, _ := r3(rng)
+	a, _ := r3.Run(rng)
 	actual := a
 	if len(actual) != 2 || actual[0] < start || actual[0] > (endExclusive-1) || actual[1] < start || actual[1] > (endExclusive-1) {
 		t.Errorf("Map2 should have produced an array of two numbers >= 1 and < 5 but produced %v \n", actual)
@@ -46,7 +46,7 @@ func TestMap3WithInt(t *testing.T) {
 		return a - b + c
 	}
 	res := Map3(ra, rb, rc, f)
-	actual, _ := res(rng)
+	actual, _ := res.Run(rng)
 	if actual != 13 {
 		t.Errorf("Map3 should have summed A - B + c to 13 but resulted in %v \n", actual)
 	}
@@ -62,7 +62,7 @@ func TestMap4WithInt(t *testing.T) {
 		return a + (b - c) + d
 	}
 	res := Map4(ra, rb, rc, rd, f)
-	actual, _ := res(rng)
+	actual, _ := res.Run(rng)
 	if actual != 26 {
 		t.Errorf("Map4 should have summed A  + (B  - c) + d to 26 but resulted in %v \n", actual)
 	}
@@ -94,7 +94,7 @@ func TestMap32WithALotOfFunctionComposition(t *testing.T) {
 			return true, nil
 		},
 	)
-	result := actual.Run(RunParms{100, rng})
+	result := actual.Run(RunParms{TestCases: 100, Rng: rng})
 	ExpectSuccess[string](t, result)
 }
 
@@ -186,7 +186,7 @@ func TestMap32AndBySurrogateMap16AndMap8(t *testing.T) {
 		return goose{a, b, c, d, e, f, g, h, i, j, k, l, m, n, o, p, q, r, s, t, u, v, w, x, y, z, aa, bb, cc, dd, ee, ff}
 	}
 	res := Map32(ra, rb, rc, rd, re, rf, rg, rh, ri, rj, rk, rl, rm, rn, ro, rp, rq, rr, rs, rt, ru, rv, rw, rx, ry, rz, raa, rbb, rcc, rdd, ree, rff, f)
-	actual, _ := res(rng)
+	actual, _ := res.Run(rng)
 	if actual != expected {
 		t.Errorf("Map32 did not map correctly \nactual:  %v\nexpected:%v\n", actual, expected)
 	}
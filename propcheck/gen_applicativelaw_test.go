@@ -27,7 +27,7 @@ type stringDouble struct {
 	b string
 }
 
-func naturalityOfProductLaw(t *testing.T, rng SimpleRNG, fa func(SimpleRNG) (int, SimpleRNG), fb func(SimpleRNG) (int, SimpleRNG), faf func(int) int, fbf func(int) int) {
+func naturalityOfProductLaw(t *testing.T, rng SimpleRNG, fa Gen[int], fb Gen[int], faf func(int) int, fbf func(int) int) {
 	productF := func(f, g func(int) int) func(int, int) stringDouble {
 		return func(x int, y int) stringDouble {
 			a := f(x)
@@ -39,8 +39,8 @@ func naturalityOfProductLaw(t *testing.T, rng SimpleRNG, fa func(SimpleRNG) (int
 	r1 := Map(fa, faf)
 	r2 := Map(fb, fbf)
 	r := Product(r1, r2)
-	rl, _ := l(rng)
-	rr, _ := r(rng)
+	rl, _ := l.Run(rng)
+	rr, _ := r.Run(rng)
 	rla := rl.a
 	lar, _ := strconv.Atoi(rla)
 	rar := rr.A
@@ -1,40 +1,41 @@
 package propcheck
 
-func fId[A any](a A) func(SimpleRNG) (A, SimpleRNG) {
-	return func(r SimpleRNG) (A, SimpleRNG) {
+func fId[A any](a A) Gen[A] {
+	return NewGen(func(r RNG) (A, RNG) {
 		return a, r
-	}
+	})
 }
 
-func Id[A any](a A) func(SimpleRNG) (A, SimpleRNG) {
+func Id[A any](a A) Gen[A] {
 	return fId(a)
 }
 
-func pFlatMap[A, B any](f func(SimpleRNG) (A, SimpleRNG), g func(A) func(SimpleRNG) (B, SimpleRNG)) func(SimpleRNG) (B, SimpleRNG) {
-	return func(rng SimpleRNG) (B, SimpleRNG) {
-		a, r1 := f(rng)
-		b, r2 := g(a)(r1)
+func pFlatMap[A, B any](f Gen[A], g func(A) Gen[B]) Gen[B] {
+	return genWithSize(func(rng RNG, size Size) (B, RNG) {
+		a, r1 := f.run(rng, size)
+		b, r2 := g(a).run(r1, size)
 		return b, r2
-	}
+	})
 }
 
-func FlatMap[A, B any](f func(SimpleRNG) (A, SimpleRNG), g func(A) func(SimpleRNG) (B, SimpleRNG)) func(SimpleRNG) (B, SimpleRNG) {
+func FlatMap[A, B any](f Gen[A], g func(A) Gen[B]) Gen[B] {
 	return pFlatMap(f, g)
 }
 
-func pMap[A, B any](s func(SimpleRNG) (A, SimpleRNG), f func(A) B) func(SimpleRNG) (B, SimpleRNG) {
-	return func(rng SimpleRNG) (B, SimpleRNG) {
-		fa := func(a A) func(SimpleRNG) (B, SimpleRNG) { return Id(f(a)) }
+func pMap[A, B any](s Gen[A], f func(A) B) Gen[B] {
+	return genWithSize(func(rng RNG, size Size) (B, RNG) {
+		fa := func(a A) Gen[B] { return Id(f(a)) }
 		r := FlatMap(s, fa)
-		return r(rng)
-	}
+		return r.run(rng, size)
+	})
 }
 
-func Map[A, B any](s func(SimpleRNG) (A, SimpleRNG), f func(A) B) func(SimpleRNG) (B, SimpleRNG) {
+func Map[A, B any](s Gen[A], f func(A) B) Gen[B] {
 	return pMap(s, f)
 }
 
-func Sequence[T any](rs []func(SimpleRNG) (T, SimpleRNG)) func(SimpleRNG) ([]T, SimpleRNG) {
+// Turns a list of generators into a generator of lists. The resulting Gen shrinks each element with its own generator's Shrinker.
+func Sequence[T any](rs []Gen[T]) Gen[[]T] {
 	var f []T
 	var g = Id(f)
 	h := func(accum []T, b T) []T {
@@ -43,5 +44,20 @@ func Sequence[T any](rs []func(SimpleRNG) (T, SimpleRNG)) func(SimpleRNG) ([]T,
 	for _, r := range rs {
 		g = Map2(g, r, h)
 	}
-	return g
+	shrink := func(xs []T) [][]T {
+		var r [][]T
+		for i, x := range xs {
+			if i >= len(rs) || rs[i].shrink == nil {
+				continue
+			}
+			for _, s := range rs[i].shrink(x) {
+				c := make([]T, len(xs))
+				copy(c, xs)
+				c[i] = s
+				r = append(r, c)
+			}
+		}
+		return r
+	}
+	return g.WithShrinker(shrink)
 }
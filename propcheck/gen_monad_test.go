@@ -38,8 +38,8 @@ func TestNextIntProducesDifferentGeneratorsEveryTime(t *testing.T) {
 func TestIdIsAPureFunction(t *testing.T) {
 	u := Id("hello")
 	rng1 := SimpleRNG{Seed: time.Now().Nanosecond()}
-	r, rng2 := u(rng1)
-	r2, rng3 := u(rng1)
+	r, rng2 := u.Run(rng1)
+	r2, rng3 := u.Run(rng1)
 	n3, _ := NextInt(rng1)
 	n4, _ := NextInt(rng1)
 	n5, _ := NextInt(rng1)
@@ -57,11 +57,11 @@ func TestIdIsAPureFunction(t *testing.T) {
 func TestFlatMapWithInt(t *testing.T) {
 	rng := SimpleRNG{time.Now().Nanosecond()}
 	r := Id(12)
-	g := func(x int) func(SimpleRNG) (int, SimpleRNG) {
+	g := func(x int) Gen[int] {
 		return Id(x + 1)
 	}
 	res := FlatMap(r, g)
-	actual, rng2 := res(rng)
+	actual, rng2 := res.Run(rng)
 	if actual != 13 {
 		t.Errorf("Map should have incremented the unit value by 1 \n")
 	}
@@ -73,11 +73,11 @@ func TestFlatMapWithInt(t *testing.T) {
 func TestFlatMapWithStringArray(t *testing.T) {
 	rng := SimpleRNG{time.Now().Nanosecond()}
 	r := Id([]string{"asd", "aDS"})
-	g := func(x []string) func(SimpleRNG) ([]string, SimpleRNG) {
+	g := func(x []string) Gen[[]string] {
 		return Id(append(x, "dude"))
 	}
 	res := FlatMap(r, g)
-	actual, rng2 := res(rng)
+	actual, rng2 := res.Run(rng)
 	expectedA := []string{"asd", "aDS", "dude"}
 	if diff := deep.Equal(actual, expectedA); diff != nil {
 		t.Error(diff)
@@ -94,7 +94,7 @@ func TestMap(t *testing.T) {
 		return x + 1
 	}
 	res := Map(r, g)
-	actual, rng2 := res(rng)
+	actual, rng2 := res.Run(rng)
 	if actual != 13 {
 		t.Errorf("Map should have incremented the unit value by 1 \n")
 	}
@@ -108,12 +108,12 @@ func TestGenerateSequenceOfRandomInts(t *testing.T) {
 	rng := SimpleRNG{time.Now().Nanosecond()}
 	start := 1
 	endExclusive := 500
-	var s []func(SimpleRNG) (int, SimpleRNG)
+	var s []Gen[int]
 	for x := 0; x < rSize; x++ {
 		s = append(s, ChooseInt(start, endExclusive))
 	}
 	u := Sequence(s)
-	actual, _ := u(rng)
+	actual, _ := u.Run(rng)
 	if len(actual) != rSize {
 		t.Errorf("Sequence should have produced an array of %v numbers but produced an array of size:%v \n", rSize, len(actual))
 	}
@@ -128,12 +128,12 @@ func TestGenerateSequenceOfRandomInts(t *testing.T) {
 func TestGenerateSequenceOfRandomFloats(t *testing.T) {
 	rSize := 1000
 	rng := SimpleRNG{time.Now().Nanosecond()}
-	var s []func(SimpleRNG) (float64, SimpleRNG)
+	var s []Gen[float64]
 	for x := 0; x < rSize; x++ {
 		s = append(s, Float())
 	}
 	u := Sequence(s)
-	actual, _ := u(rng)
+	actual, _ := u.Run(rng)
 	if len(actual) != rSize {
 		t.Errorf("Sequence should have produced an array of %v numbers but produced an array of size:%v \n", rSize, len(actual))
 	}
@@ -142,12 +142,12 @@ func TestGenerateSequenceOfRandomFloats(t *testing.T) {
 func TestGenerateSequenceOfRandomBools(t *testing.T) {
 	rSize := 1000
 	rng := SimpleRNG{time.Now().Nanosecond()}
-	var s []func(SimpleRNG) (bool, SimpleRNG)
+	var s []Gen[bool]
 	for x := 0; x < rSize; x++ {
 		s = append(s, Boolean())
 	}
 	u := Sequence(s)
-	actual, _ := u(rng)
+	actual, _ := u.Run(rng)
 	if len(actual) != rSize {
 		t.Errorf("Sequence should have produced an array of %v bools but produced an array of size:%v \n", rSize, len(actual))
 	}
@@ -1,6 +1,7 @@
 package propcheck
 
 import (
+	"fmt"
 	"github.com/go-test/deep"
 	"testing"
 	"time"
@@ -25,10 +26,10 @@ func TestMapAssociativeLaw(t *testing.T) {
 	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
 	l1 := Product(fa, fb)
 	l2 := Product(l1, fc)
-	l, _ := l2(rng)
+	l, _ := l2.Run(rng)
 	r1 := Product(fb, fc)
 	r2 := Product(fa, r1)
-	r, _ := Map(r2, assoc)(rng)
+	r, _ := Map(r2, assoc).Run(rng)
 	if diff := deep.Equal(l, r); diff != nil {
 		t.Error(diff)
 	}
@@ -42,12 +43,33 @@ func TestMap2RightAndLeftIdentityLaw(t *testing.T) {
 	}
 	ge := ChooseInt(0, 1000)
 	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
-	v, _ := ge(rng)
+	v, _ := ge.Run(rng)
 	li := Map2(Id(v), ge, fl)
 	ri := Map2(ge, Id(v), fl)
-	l, _ := li(rng)
-	r, _ := ri(rng)
+	l, _ := li.Run(rng)
+	r, _ := ri.Run(rng)
 	if l != r || l != v {
 		t.Errorf("l and r and v should have matched and they were instead: %v, %v, %v", l, r, v)
 	}
 }
+
+//Composition Law for Functors - Mapping A Gen with f and then with g should produce the same thing as mapping it once with g after f.
+//f and g are random functions so the law is checked for many functions instead of A hand-written one.
+func TestMapCompositionLaw(t *testing.T) {
+	ge := ChooseInt(0, 1000)
+	funs := Product(FunOf(CogenInteger[int](), Int()), FunOf(CogenInteger[int](), String(10)))
+	prop := ForAll(Product(funs, Int()), "Map(Map(ge, f), g) == Map(ge, g after f)",
+		func(p Pair[Pair[Fun[int, int], Fun[int, string]], int]) Pair[Pair[Fun[int, int], Fun[int, string]], int] { return p },
+		func(p Pair[Pair[Fun[int, int], Fun[int, string]], int]) (bool, error) {
+			f, g, rng := p.A.A, p.A.B, SimpleRNG{Seed: p.B}
+			l, _ := Map(Map(ge, f.Apply), g.Apply).Run(rng)
+			r, _ := Map(ge, func(x int) string { return g.Apply(f.Apply(x)) }).Run(rng)
+			if l != r {
+				return false, fmt.Errorf("%q and %q should have been the same", l, r)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: SimpleRNG{Seed: time.Now().Nanosecond()}})
+	ExpectSuccess[Pair[Pair[Fun[int, int], Fun[int, string]], int]](t, result)
+}
@@ -33,7 +33,7 @@ func TestStringGenerator(t *testing.T) {
 			}
 			return true, nil
 		})
-	_ = And[string](mustBeANonZerolengthString, mustBeAZeroLengthString).Run(RunParms{500, rng}) //Result does not matter. You have to look at the closure to verify.
+	_ = And[string](mustBeANonZerolengthString, mustBeAZeroLengthString).Run(RunParms{TestCases: 500, Rng: rng}) //Result does not matter. You have to look at the closure to verify.
 	if !zeroLengthString {
 		t.Errorf("There should have been A zero length string. \n")
 	}
@@ -57,7 +57,7 @@ func TestFloatGenerator(t *testing.T) {
 			}
 		},
 	)
-	result := mustBeFloat.Run(RunParms{200, rng})
+	result := mustBeFloat.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[float64](t, result)
 }
 
@@ -77,7 +77,7 @@ func TestChooseInt(t *testing.T) {
 			}
 		},
 	)
-	result := mustBeInRange.Run(RunParms{200, rng})
+	result := mustBeInRange.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[int](t, result)
 }
 
@@ -102,7 +102,7 @@ func TestChooseDate(t *testing.T) {
 			}
 		},
 	)
-	result := mustBeBoolean.Run(RunParms{200, rng})
+	result := mustBeBoolean.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[time.Time](t, result)
 }
 
@@ -120,7 +120,7 @@ func TestNonNegativeInt(t *testing.T) {
 			}
 		},
 	)
-	result := mustBePositiveInt.Run(RunParms{200, rng})
+	result := mustBePositiveInt.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[int](t, result)
 }
 
@@ -147,7 +147,7 @@ func TestThatMap2IsContextSensitive(t *testing.T) {
 			}
 		},
 	)
-	result := test.Run(RunParms{200, rng})
+	result := test.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[Pair[int, int]](t, result)
 }
 
@@ -183,7 +183,7 @@ func TestArrayOfNWithForAll(t *testing.T) {
 			return true, nil
 		})
 	test := And[[]int](correctLength, elementsInRange)
-	result := test.Run(RunParms{200, rng})
+	result := test.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
 
@@ -205,7 +205,7 @@ func TestWeightedNoWeightOnFirst(t *testing.T) {
 		},
 	)
 	rng := SimpleRNG{time.Now().Nanosecond()}
-	result := lengthInRange.Run(RunParms{200, rng})
+	result := lengthInRange.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[int](t, result)
 }
 
@@ -235,7 +235,7 @@ func TestWeightedFailureLastSuccessCase(t *testing.T) {
 		},
 	)
 	rng := SimpleRNG{time.Now().Nanosecond()}
-	result := lengthInRange.Run(RunParms{200, rng})
+	result := lengthInRange.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
 
@@ -259,7 +259,7 @@ func TestWeighted(t *testing.T) {
 		return true, nil
 	}
 	test := ForAll(u, "Weighted should have produced A number between 1000 and 5000 exclusive or between 100000 and 200000 exclusive.", checker, assertion)
-	ExpectSuccess[int](t, test.Run(RunParms{200, rng}))
+	ExpectSuccess[int](t, test.Run(RunParms{TestCases: 200, Rng: rng}))
 }
 
 func TestChooseArrayWillProduceListOfZeroElements(t *testing.T) {
@@ -278,7 +278,7 @@ func TestChooseArrayWillProduceListOfZeroElements(t *testing.T) {
 			}
 		},
 	)
-	result := lengthZero.Run(RunParms{200, rng})
+	result := lengthZero.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
 
@@ -311,6 +311,46 @@ func TestChooseArrayWillProduceListOfAtLeastMinElementsAndNotMoreThanHighRange(t
 		},
 	)
 	bigProp := And[[]int](lengthGEOne, lengthLEMax)
-	result := bigProp.Run(RunParms{200, rng})
+	result := bigProp.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
+
+func TestChooseMapProducesEntriesInRange(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	seen := map[int]bool{}
+	for _, m := range ChooseMap(2, 5, ChooseInt(0, 1000), Boolean()).Sample(rng, 200) {
+		if len(m) < 2 || len(m) > 5 {
+			t.Errorf("%v should have had between 2 and 5 entries", m)
+		}
+		seen[len(m)] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("Every length in [2, 5] should have been produced but only %v were", seen)
+	}
+	for _, m := range ChooseMap(3, 3, Boolean(), Int()).Sample(rng, 20) {
+		if len(m) != 2 {
+			t.Errorf("A map with bool keys can only have 2 entries but had %v", m)
+		}
+	}
+}
+
+func TestMapOfShrinksEntries(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(MapOf(ChooseInt(0, 100), ChooseInt(0, 100)), "Maps have no value over 50",
+		func(m map[int]int) map[int]int { return m },
+		func(m map[int]int) (bool, error) {
+			for k, v := range m {
+				if v > 50 {
+					return false, fmt.Errorf("%v had the value %v", k, v)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[map[int]int](t, result)
+	f := result.(Falsified[map[int]int])
+	if len(f.FailedCase) != 1 || f.FailedCase[0] != 51 {
+		t.Errorf("The counterexample should have shrunk to map[0:51] but was %v", f.FailedCase)
+	}
+}
@@ -0,0 +1,120 @@
+package propcheck
+
+import "fmt"
+
+// The number of times Filter will draw A new value before giving up.
+const MaxFilterTries = 100
+
+// A Gen is A generator of random values of type A. It wraps the function that does the generating, taking an RNG and returning
+// the generated value together with the next RNG, and carries the optional extras that belong with A generator such as
+// its Shrinker and A descriptive label. Every Gen is also given A Size when it runs, see Sized.
+//
+// A Gen is immutable. Every method that changes A Gen returns A new one, so generators remain freely shareable between Go Routines.
+type Gen[A any] struct {
+	run      func(RNG, Size) (A, RNG)
+	shrink   Shrinker[A]
+	label    string
+	classify []func(A) string      //See Classify and Collect.
+	cover    []coverRequirement[A] //See Cover.
+}
+
+// Makes A Gen from A bare generator function. This is the adapter for custom generators written as "func(RNG) (A, RNG)".
+// The resulting Gen ignores its Size.
+func NewGen[A any](run func(RNG) (A, RNG)) Gen[A] {
+	return genWithSize(func(rng RNG, _ Size) (A, RNG) {
+		return run(rng)
+	})
+}
+
+func genWithSize[A any](run func(RNG, Size) (A, RNG)) Gen[A] {
+	return Gen[A]{run: run}
+}
+
+// Generates A value with A Size of DefaultMaxSize and returns it along with the next RNG.
+func (g Gen[A]) Run(rng RNG) (A, RNG) {
+	return g.run(rng, DefaultMaxSize)
+}
+
+// Generates A value with the given Size and returns it along with the next RNG.
+func (g Gen[A]) RunSized(rng RNG, size Size) (A, RNG) {
+	return g.run(rng, size)
+}
+
+// Generates n values starting from the given RNG with A Size of DefaultMaxSize. Useful for eyeballing what A generator produces.
+func (g Gen[A]) Sample(rng RNG, n int) []A {
+	var r []A
+	var a A
+	for x := 0; x < n; x++ {
+		a, rng = g.run(rng, DefaultMaxSize)
+		r = append(r, a)
+	}
+	return r
+}
+
+// Returns A Gen that only produces values satisfying the predicate p. Values that do not satisfy p are thrown away and A new value is drawn,
+// up to MaxFilterTries times, after which the Gen panics. Shrink candidates are filtered the same way.
+func (g Gen[A]) Filter(p func(A) bool) Gen[A] {
+	run := func(rng RNG, size Size) (A, RNG) {
+		var a A
+		for x := 0; x < MaxFilterTries; x++ {
+			a, rng = g.run(rng, size)
+			if p(a) {
+				return a, rng
+			}
+		}
+		panic(fmt.Sprintf("Filter on Gen%v could not produce a value satisfying its predicate after %v tries", g.labelSuffix(), MaxFilterTries))
+	}
+	r := g
+	r.run = run
+	r.shrink = filterShrinker(g.shrink, p)
+	return r
+}
+
+// Returns A Shrinker that only produces the candidates of s satisfying p, or nil if s is nil.
+func filterShrinker[A any](s Shrinker[A], p func(A) bool) Shrinker[A] {
+	if s == nil {
+		return nil
+	}
+	return func(a A) []A {
+		var c []A
+		for _, x := range s(a) {
+			if p(x) {
+				c = append(c, x)
+			}
+		}
+		return c
+	}
+}
+
+// Returns A copy of the Gen that shrinks failing values with s.
+func (g Gen[A]) WithShrinker(s Shrinker[A]) Gen[A] {
+	g.shrink = s
+	return g
+}
+
+// Returns A copy of the Gen with A descriptive label.
+func (g Gen[A]) WithLabel(label string) Gen[A] {
+	g.label = label
+	return g
+}
+
+// The Shrinker of the Gen, or nil if it has none.
+func (g Gen[A]) Shrinker() Shrinker[A] {
+	return g.shrink
+}
+
+// The descriptive label of the Gen, or the empty string if it has none.
+func (g Gen[A]) Label() string {
+	return g.label
+}
+
+func (g Gen[A]) String() string {
+	return fmt.Sprintf("Gen%v", g.labelSuffix())
+}
+
+func (g Gen[A]) labelSuffix() string {
+	if g.label == "" {
+		return fmt.Sprintf("[%T]", *new(A))
+	}
+	return fmt.Sprintf("[%T]{%v}", *new(A), g.label)
+}
@@ -0,0 +1,122 @@
+package propcheck
+
+import (
+	"fmt"
+	"github.com/go-test/deep"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGenAdaptsABareGeneratorFunction(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := NewGen(func(r RNG) (int, RNG) {
+		return r.NextInt()
+	})
+	actual, rng2 := g.Run(rng)
+	expected, rng3 := NextInt(rng)
+	if actual != expected || rng2 != rng3 {
+		t.Errorf("Gen should have produced the same value and SimpleRNG as the function it wraps")
+	}
+}
+
+func TestSampleIsRepeatable(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseInt(0, 1000)
+	l := g.Sample(rng, 20)
+	r := g.Sample(rng, 20)
+	if len(l) != 20 {
+		t.Errorf("Sample should have produced 20 values but produced %v", len(l))
+	}
+	if diff := deep.Equal(l, r); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	even := func(x int) bool { return x%2 == 0 }
+	prop := ForAll(ChooseInt(0, 1000).Filter(even), "Filtered numbers must be even.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if !even(x) {
+				return false, fmt.Errorf("%v was odd", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	ExpectSuccess[int](t, result)
+}
+
+func TestFilterAlsoFiltersShrinkCandidates(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	for _, c := range Int().Filter(even).Shrinker()(1000) {
+		if !even(c) {
+			t.Errorf("Shrink candidate %v should have been filtered out", c)
+		}
+	}
+}
+
+func TestFilterPanicsWhenPredicateCannotBeSatisfied(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Errorf("Filter should have panicked")
+		}
+	}()
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	ChooseInt(0, 10).Filter(func(x int) bool { return x > 10 }).Run(rng)
+}
+
+func TestWithLabel(t *testing.T) {
+	g := String(10).WithLabel("user name")
+	if g.Label() != "user name" {
+		t.Errorf("Label should have been user name but was %v", g.Label())
+	}
+	if !strings.Contains(g.String(), "user name") {
+		t.Errorf("String should have contained the label but was %v", g)
+	}
+}
+
+func TestChooseIntShrinksWithinItsRange(t *testing.T) {
+	s := ChooseInt(10, 20).Shrinker()
+	for _, c := range s(19) {
+		if c < 10 || c >= 20 {
+			t.Errorf("Shrink candidate %v was out of range", c)
+		}
+	}
+	if diff := deep.Equal(s(19)[0], 10); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestChooseArrayShrinksByDefault(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseArray(3, 200, ChooseInt(-100, 100)), "Array must not contain a number greater than 50.",
+		func(xs []int) []int { return xs },
+		func(xs []int) (bool, error) {
+			for _, x := range xs {
+				if x > 50 {
+					return false, fmt.Errorf("%v was greater than 50", x)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[[]int]:
+		if len(v.FailedCase) != 3 {
+			t.Errorf("Shrinking should have stopped at the minimum length of 3 but was %v", v.FailedCase)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestMapDropsTheShrinker(t *testing.T) {
+	g := Map(Int(), func(x int) string { return fmt.Sprintf("%v", x) })
+	if g.Shrinker() != nil {
+		t.Errorf("Map cannot know how to shrink its result and should not have a Shrinker")
+	}
+}
@@ -0,0 +1,122 @@
+package laws
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Go has no higher-kinded types, so these laws take A type FA that holds values of type A, such as []A, option.Option[A] or
+// propcheck.Gen[A], along with its operations specialized to functions from A to A. Random functions are generated with
+// propcheck.FunOf from cogen and as.
+
+// Makes A Prop named name that checks the law for every generated value.
+func forAll[A any](g propcheck.Gen[A], name string, law func(A) error) propcheck.Prop {
+	return propcheck.ForAll(g, name,
+		func(a A) A { return a },
+		func(a A) (bool, error) {
+			if err := law(a); err != nil {
+				return false, err
+			}
+			return true, nil
+		},
+	)
+}
+
+// The laws of A Functor: mapping the identity function changes nothing, and mapping f and then g is the same as mapping g after f.
+func Functor[A, FA any](fa propcheck.Gen[FA], cogen propcheck.Cogen[A], as propcheck.Gen[A], fmap func(FA, func(A) A) FA,
+	eq func(l, r FA) bool) []propcheck.Prop {
+	fun := propcheck.FunOf(cogen, as)
+	return []propcheck.Prop{
+		forAll(fa, "Functor identity", func(x FA) error {
+			if r := fmap(x, func(a A) A { return a }); !eq(r, x) {
+				return fmt.Errorf("mapping the identity function over %v gave %v", x, r)
+			}
+			return nil
+		}),
+		forAll(propcheck.Product(fa, propcheck.Product(fun, fun)), "Functor composition", func(p propcheck.Pair[FA, propcheck.Pair[propcheck.Fun[A, A], propcheck.Fun[A, A]]]) error {
+			x, f, g := p.A, p.B.A, p.B.B
+			l := fmap(fmap(x, f.Apply), g.Apply)
+			r := fmap(x, func(a A) A { return g.Apply(f.Apply(a)) })
+			if !eq(l, r) {
+				return fmt.Errorf("mapping f=%v and then g=%v over %v gave %v but mapping g after f gave %v", f, g, x, l, r)
+			}
+			return nil
+		}),
+	}
+}
+
+/*
+*
+The laws of an Applicative Functor in terms of pure and map2: combining with A pure value on either side changes nothing(left and right identity),
+combining pure values is pure(homomorphism), and map2 is associative for associative functions, which is checked with the projections
+(x, y) -> x and (x, y) -> y so that the order and number of effects are the same however calls are nested.
+*/
+func Applicative[A, FA any](fa propcheck.Gen[FA], cogen propcheck.Cogen[A], as propcheck.Gen[A], pure func(A) FA,
+	map2 func(FA, FA, func(A, A) A) FA, eq func(l, r FA) bool) []propcheck.Prop {
+	first := func(x, _ A) A { return x }
+	second := func(_, y A) A { return y }
+	fun := propcheck.FunOf(propcheck.CogenPair(cogen, cogen), as)
+	return []propcheck.Prop{
+		forAll(propcheck.Product(fa, as), "Applicative left identity", func(p propcheck.Pair[FA, A]) error {
+			if r := map2(pure(p.B), p.A, second); !eq(r, p.A) {
+				return fmt.Errorf("combining pure(%v) with %v gave %v", p.B, p.A, r)
+			}
+			return nil
+		}),
+		forAll(propcheck.Product(fa, as), "Applicative right identity", func(p propcheck.Pair[FA, A]) error {
+			if r := map2(p.A, pure(p.B), first); !eq(r, p.A) {
+				return fmt.Errorf("combining %v with pure(%v) gave %v", p.A, p.B, r)
+			}
+			return nil
+		}),
+		forAll(propcheck.Product(fun, propcheck.Product(as, as)), "Applicative homomorphism", func(p propcheck.Pair[propcheck.Fun[propcheck.Pair[A, A], A], propcheck.Pair[A, A]]) error {
+			f, a, b := p.A, p.B.A, p.B.B
+			apply := func(x, y A) A { return f.Apply(propcheck.Pair[A, A]{A: x, B: y}) }
+			if l, r := map2(pure(a), pure(b), apply), pure(apply(a, b)); !eq(l, r) {
+				return fmt.Errorf("combining pure(%v) and pure(%v) with f=%v gave %v but pure(f(%v, %v)) was %v", a, b, f, l, a, b, r)
+			}
+			return nil
+		}),
+		forAllTriples(triples(fa), "Applicative associativity", func(t triple[FA]) error {
+			for _, f := range []func(A, A) A{first, second} {
+				l, r := map2(map2(t.X, t.Y, f), t.Z, f), map2(t.X, map2(t.Y, t.Z, f), f)
+				if !eq(l, r) {
+					return fmt.Errorf("map2(map2(x, y), z) was %v but map2(x, map2(y, z)) was %v", l, r)
+				}
+			}
+			return nil
+		}),
+	}
+}
+
+// The laws of A Monad in terms of pure and flatMap: flatMap of A pure value applies the function(left identity), flatMap with pure changes
+// nothing(right identity), and nested flatMaps are the same however they are nested(associativity). Random functions from A to FA are
+// generated from cogen and fa.
+func Monad[A, FA any](fa propcheck.Gen[FA], cogen propcheck.Cogen[A], as propcheck.Gen[A], pure func(A) FA,
+	flatMap func(FA, func(A) FA) FA, eq func(l, r FA) bool) []propcheck.Prop {
+	fun := propcheck.FunOf(cogen, fa)
+	return []propcheck.Prop{
+		forAll(propcheck.Product(fun, as), "Monad left identity", func(p propcheck.Pair[propcheck.Fun[A, FA], A]) error {
+			f, a := p.A, p.B
+			if l, r := flatMap(pure(a), f.Apply), f.Apply(a); !eq(l, r) {
+				return fmt.Errorf("flatMap(pure(%v), f=%v) was %v but f(%v) was %v", a, f, l, a, r)
+			}
+			return nil
+		}),
+		forAll(fa, "Monad right identity", func(x FA) error {
+			if r := flatMap(x, pure); !eq(r, x) {
+				return fmt.Errorf("flatMap(%v, pure) was %v", x, r)
+			}
+			return nil
+		}),
+		forAll(propcheck.Product(fa, propcheck.Product(fun, fun)), "Monad associativity", func(p propcheck.Pair[FA, propcheck.Pair[propcheck.Fun[A, FA], propcheck.Fun[A, FA]]]) error {
+			x, f, g := p.A, p.B.A, p.B.B
+			l := flatMap(flatMap(x, f.Apply), g.Apply)
+			r := flatMap(x, func(a A) FA { return flatMap(f.Apply(a), g.Apply) })
+			if !eq(l, r) {
+				return fmt.Errorf("flatMap(flatMap(%v, f=%v), g=%v) was %v but flatMap(x, a -> flatMap(f(a), g)) was %v", x, f, g, l, r)
+			}
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,81 @@
+package laws
+
+import (
+	"github.com/greymatter-io/golangz/arrays"
+	"github.com/greymatter-io/golangz/option"
+	"github.com/greymatter-io/golangz/propcheck"
+	"reflect"
+	"testing"
+)
+
+func eqInts(l, r []int) bool {
+	return arrays.ArrayEquality(l, r, eqInt)
+}
+
+func eqOption(l, r option.Option[int]) bool {
+	return reflect.DeepEqual(l, r)
+}
+
+var ints = propcheck.ChooseInt(-100, 100)
+
+var intArrays = propcheck.SizedArray(ints)
+
+var cogenInt = propcheck.CogenInteger[int]()
+
+func pureArray(a int) []int {
+	return []int{a}
+}
+
+func map2Array(l, r []int, f func(int, int) int) []int {
+	return arrays.FlatMap(l, func(a int) []int { return arrays.Map(r, func(b int) int { return f(a, b) }) })
+}
+
+func TestArrayLaws(t *testing.T) {
+	Check(t, Functor(intArrays, cogenInt, ints, arrays.Map[int, int], eqInts))
+	Check(t, Applicative(propcheck.ChooseArray(0, 4, ints), cogenInt, ints, pureArray, map2Array, eqInts))
+	Check(t, Monad(propcheck.ChooseArray(0, 4, ints), cogenInt, ints, pureArray, arrays.FlatMap[int, int], eqInts))
+	dropsLast := func(xs []int, f func(int) int) []int {
+		r := arrays.Map(xs, f)
+		if len(r) > 1 {
+			return r[:len(r)-1]
+		}
+		return r
+	}
+	expectOnlyFalsified(t, Functor(intArrays, cogenInt, ints, dropsLast, eqInts)[:1], "Functor identity")
+	zip := func(l, r []int, f func(int, int) int) []int {
+		var z []int
+		for i := 0; i < len(l) && i < len(r); i++ {
+			z = append(z, f(l[i], r[i]))
+		}
+		return z
+	}
+	expectOnlyFalsified(t, Applicative(propcheck.ChooseArray(0, 4, ints), cogenInt, ints, pureArray, zip, eqInts)[:1], "Applicative left identity")
+}
+
+func TestOptionLaws(t *testing.T) {
+	options := option.OptionOf(ints, 4)
+	pure := func(a int) option.Option[int] { return option.Some[int]{Value: a} }
+	map2 := func(l, r option.Option[int], f func(int, int) int) option.Option[int] {
+		return option.FlatMap(l, func(a int) option.Option[int] {
+			return option.Map(r, func(b int) int { return f(a, b) })
+		})
+	}
+	Check(t, Functor(options, cogenInt, ints, option.Map[int, int], eqOption))
+	Check(t, Applicative(options, cogenInt, ints, pure, map2, eqOption))
+	Check(t, Monad(options, cogenInt, ints, pure, option.FlatMap[int, int], eqOption))
+}
+
+func TestGenMonadLaws(t *testing.T) {
+	gens := propcheck.Map(propcheck.ChooseInt(1, 100), func(n int) propcheck.Gen[int] { return propcheck.ChooseInt(-n, n) })
+	eqGen := func(l, r propcheck.Gen[int]) bool {
+		for seed := 0; seed < 10; seed++ {
+			rng := propcheck.SimpleRNG{Seed: seed}
+			if !reflect.DeepEqual(l.Sample(rng, 5), r.Sample(rng, 5)) {
+				return false
+			}
+		}
+		return true
+	}
+	Check(t, Functor(gens, cogenInt, ints, propcheck.Map[int, int], eqGen))
+	Check(t, Monad(gens, cogenInt, ints, propcheck.Id[int], propcheck.FlatMap[int, int], eqGen))
+}
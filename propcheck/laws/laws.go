@@ -0,0 +1,191 @@
+/*
+*
+Package laws provides ready-made suites of properties for the laws that instances of common type classes must obey: Semigroup, Monoid,
+Eq, Ord, Fold, Functor, Applicative and Monad. Each suite is parameterized by generators and by the operations of the instance, and returns
+one named propcheck.Prop per law, so A whole suite can be checked with one call to Check:
+
+	laws.Check(t, laws.Ord(propcheck.ChooseInt(-100, 100), lt, eq))
+*/
+package laws
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+)
+
+// Runs each law as A subtest of t with propcheck.Check and the given Options.
+func Check(t *testing.T, laws []propcheck.Prop, opts ...propcheck.Option) {
+	t.Helper()
+	for _, l := range laws {
+		propcheck.Check(t, l, opts...)
+	}
+}
+
+// Three values of the same type, the inputs of laws such as associativity and transitivity.
+type triple[A any] struct {
+	X, Y, Z A
+}
+
+func (w triple[A]) String() string {
+	return fmt.Sprintf("{%v, %v, %v}", w.X, w.Y, w.Z)
+}
+
+// Generates three independent values from g. The Gen shrinks each value with the Shrinker of g.
+func triples[A any](g propcheck.Gen[A]) propcheck.Gen[triple[A]] {
+	to := func(p propcheck.Pair[A, propcheck.Pair[A, A]]) triple[A] { return triple[A]{p.A, p.B.A, p.B.B} }
+	from := func(t triple[A]) propcheck.Pair[A, propcheck.Pair[A, A]] {
+		return propcheck.Pair[A, propcheck.Pair[A, A]]{A: t.X, B: propcheck.Pair[A, A]{A: t.Y, B: t.Z}}
+	}
+	s := g.Shrinker()
+	return propcheck.Map(propcheck.Product(g, propcheck.Product(g, g)), to).
+		WithShrinker(propcheck.ShrinkConvert(propcheck.ShrinkPair(s, propcheck.ShrinkPair(s, s)), to, from))
+}
+
+// Generates three values from g where Y is A copy of X half of the time and Z is A copy of Y half of the time, so that laws about
+// equal values, such as transitivity of equality, are not satisfied vacuously. The Gen shrinks each value with the Shrinker of g.
+func relatedTriples[A any](g propcheck.Gen[A]) propcheck.Gen[triple[A]] {
+	orCopy := func(a A) propcheck.Gen[A] { return propcheck.OneOf(propcheck.Id(a), g) }
+	r := propcheck.FlatMap(g, func(x A) propcheck.Gen[triple[A]] {
+		return propcheck.FlatMap(orCopy(x), func(y A) propcheck.Gen[triple[A]] {
+			return propcheck.Map(orCopy(y), func(z A) triple[A] { return triple[A]{x, y, z} })
+		})
+	})
+	return r.WithShrinker(triples(g).Shrinker())
+}
+
+// Makes A Prop named name that checks the law for every generated triple.
+func forAllTriples[A any](g propcheck.Gen[triple[A]], name string, law func(triple[A]) error) propcheck.Prop {
+	return propcheck.ForAll(g, name,
+		func(t triple[A]) triple[A] { return t },
+		func(t triple[A]) (bool, error) {
+			if err := law(t); err != nil {
+				return false, err
+			}
+			return true, nil
+		},
+	)
+}
+
+// The laws of A Semigroup: combine is associative.
+func Semigroup[A any](g propcheck.Gen[A], combine func(A, A) A, eq func(A, A) bool) []propcheck.Prop {
+	return []propcheck.Prop{
+		forAllTriples(triples(g), "Semigroup associativity", func(t triple[A]) error {
+			l, r := combine(combine(t.X, t.Y), t.Z), combine(t.X, combine(t.Y, t.Z))
+			if !eq(l, r) {
+				return fmt.Errorf("combine(combine(x, y), z) was %v but combine(x, combine(y, z)) was %v", l, r)
+			}
+			return nil
+		}),
+	}
+}
+
+// The laws of A Monoid: the laws of A Semigroup, and empty is the identity of combine on both sides.
+func Monoid[A any](g propcheck.Gen[A], combine func(A, A) A, empty A, eq func(A, A) bool) []propcheck.Prop {
+	return append(Semigroup(g, combine, eq),
+		forAll(g, "Monoid left identity", func(x A) error {
+			if l := combine(empty, x); !eq(l, x) {
+				return fmt.Errorf("combine(empty, %v) was %v", x, l)
+			}
+			return nil
+		}),
+		forAll(g, "Monoid right identity", func(x A) error {
+			if r := combine(x, empty); !eq(r, x) {
+				return fmt.Errorf("combine(%v, empty) was %v", x, r)
+			}
+			return nil
+		}),
+	)
+}
+
+// The laws of an equivalence relation such as the eq predicates of sets.ToSet and arrays.Contains: eq is reflexive, symmetric and transitive.
+func Eq[A any](g propcheck.Gen[A], eq func(l, r A) bool) []propcheck.Prop {
+	return []propcheck.Prop{
+		forAll(g, "Eq reflexivity", func(x A) error {
+			if !eq(x, x) {
+				return fmt.Errorf("%v was not equal to itself", x)
+			}
+			return nil
+		}),
+		forAllTriples(relatedTriples(g), "Eq symmetry", func(t triple[A]) error {
+			if eq(t.X, t.Y) != eq(t.Y, t.X) {
+				return fmt.Errorf("eq(%v, %v) was %v but eq(%v, %v) was %v", t.X, t.Y, eq(t.X, t.Y), t.Y, t.X, eq(t.Y, t.X))
+			}
+			return nil
+		}),
+		forAllTriples(relatedTriples(g), "Eq transitivity", func(t triple[A]) error {
+			if eq(t.X, t.Y) && eq(t.Y, t.Z) && !eq(t.X, t.Z) {
+				return fmt.Errorf("%v equaled %v and %v equaled %v but %v did not equal %v", t.X, t.Y, t.Y, t.Z, t.X, t.Z)
+			}
+			return nil
+		}),
+	}
+}
+
+/*
+*
+The laws of A strict total order such as the lt predicates of sets.ToSet and sorting.QuickSort, and its consistency with the equivalence eq:
+the laws of Eq, lt is irreflexive(nothing is less than itself), antisymmetric(x < y and y < x never both hold) and transitive, and exactly one
+of lt(x, y), lt(y, x) and eq(x, y) holds for any x and y. A less-than-or-equal predicate breaks these laws, and so does an lt that disagrees
+with eq, which makes sets.ToSet keep duplicates.
+*/
+func Ord[A any](g propcheck.Gen[A], lt, eq func(l, r A) bool) []propcheck.Prop {
+	return append(Eq(g, eq),
+		forAll(g, "Ord irreflexivity", func(x A) error {
+			if lt(x, x) {
+				return fmt.Errorf("%v was less than itself", x)
+			}
+			return nil
+		}),
+		forAllTriples(relatedTriples(g), "Ord antisymmetry", func(t triple[A]) error {
+			if lt(t.X, t.Y) && lt(t.Y, t.X) {
+				return fmt.Errorf("%v and %v were both less than each other", t.X, t.Y)
+			}
+			return nil
+		}),
+		forAllTriples(triples(g), "Ord transitivity", func(t triple[A]) error {
+			if lt(t.X, t.Y) && lt(t.Y, t.Z) && !lt(t.X, t.Z) {
+				return fmt.Errorf("%v was less than %v and %v was less than %v but %v was not less than %v", t.X, t.Y, t.Y, t.Z, t.X, t.Z)
+			}
+			return nil
+		}),
+		forAllTriples(relatedTriples(g), "Ord consistency with Eq", func(t triple[A]) error {
+			holds := 0
+			for _, b := range []bool{lt(t.X, t.Y), lt(t.Y, t.X), eq(t.X, t.Y)} {
+				if b {
+					holds++
+				}
+			}
+			if holds != 1 {
+				return fmt.Errorf("exactly one of lt(x, y), lt(y, x) and eq(x, y) should have held for %v and %v but lt(x, y) was %v, lt(y, x) was %v and eq(x, y) was %v",
+					t.X, t.Y, lt(t.X, t.Y), lt(t.Y, t.X), eq(t.X, t.Y))
+			}
+			return nil
+		}),
+	)
+}
+
+/*
+*
+The law of A foldable structure such as A linked list or A stack: foldLeft and foldRight visit the same elements in the same order, which is
+checked by collecting the elements with each. Pass instantiations of the structure's folds, e.g.
+
+	laws.Fold(stack.StackOf(propcheck.Int()), stack.FoldLeft[int, []int], stack.FoldRight[int, []int], eq)
+*/
+func Fold[A, FA any](g propcheck.Gen[FA], foldLeft func(FA, []A, func([]A, A) []A) []A, foldRight func(FA, []A, func(A, []A) []A) []A,
+	eq func(l, r A) bool) []propcheck.Prop {
+	law := func(fa FA) (bool, error) {
+		l := foldLeft(fa, []A{}, func(accum []A, a A) []A { return append(accum, a) })
+		r := foldRight(fa, []A{}, func(a A, accum []A) []A { return append([]A{a}, accum...) })
+		if len(l) != len(r) {
+			return false, fmt.Errorf("foldLeft visited %v but foldRight visited %v", l, r)
+		}
+		for i := range l {
+			if !eq(l[i], r[i]) {
+				return false, fmt.Errorf("foldLeft visited %v but foldRight visited %v", l, r)
+			}
+		}
+		return true, nil
+	}
+	return []propcheck.Prop{propcheck.ForAll(g, "Fold consistency", func(fa FA) FA { return fa }, law)}
+}
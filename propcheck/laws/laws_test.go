@@ -0,0 +1,85 @@
+package laws
+
+import (
+	"github.com/greymatter-io/golangz/arrays"
+	"github.com/greymatter-io/golangz/linked_list"
+	"github.com/greymatter-io/golangz/propcheck"
+	"github.com/greymatter-io/golangz/stack"
+	"strings"
+	"testing"
+	"time"
+)
+
+func eqInt(l, r int) bool { return l == r }
+
+func ltInt(l, r int) bool { return l < r }
+
+// Fails the test unless the law named name is among the laws and is falsified, and every other law holds.
+func expectOnlyFalsified(t *testing.T, laws []propcheck.Prop, name string) {
+	t.Helper()
+	found := false
+	for _, l := range laws {
+		r := l.Run(propcheck.RunParms{TestCases: 1000, Rng: propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}})
+		if l.Name == name {
+			found = true
+			if !r.IsFalsified() {
+				t.Errorf("%v should have been falsified", name)
+			}
+		} else if r.IsFalsified() {
+			t.Errorf("%v should have held but was %v", l.Name, r)
+		}
+	}
+	if !found {
+		t.Errorf("There was no law named %v", name)
+	}
+}
+
+func TestMonoidLaws(t *testing.T) {
+	Check(t, Monoid(propcheck.Int(), func(l, r int) int { return l + r }, 0, eqInt))
+	Check(t, Monoid(propcheck.SizedString(), func(l, r string) string { return l + r }, "", func(l, r string) bool { return l == r }))
+	expectOnlyFalsified(t, Semigroup(propcheck.ChooseInt(-100, 100), func(l, r int) int { return l - r }, eqInt), "Semigroup associativity")
+	expectOnlyFalsified(t, Monoid(propcheck.ChooseInt(-100, 100), func(l, r int) int { return l + r }, 1, eqInt)[1:2], "Monoid left identity")
+}
+
+func TestSingleValueLawsReportOneValue(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	leftIdentity := Monoid(propcheck.ChooseInt(-100, 100), func(l, r int) int { return l + r }, 1, eqInt)[1]
+	if f, ok := leftIdentity.Run(propcheck.RunParms{TestCases: 100, Rng: rng}).(propcheck.Falsified[int]); !ok || f.FailedCase != 0 {
+		t.Errorf("Monoid left identity should have been falsified by the single value 0")
+	}
+	irreflexivity := Ord(propcheck.ChooseInt(-10, 10), func(l, r int) bool { return l <= r }, eqInt)[3]
+	if f, ok := irreflexivity.Run(propcheck.RunParms{TestCases: 100, Rng: rng}).(propcheck.Falsified[int]); !ok || f.FailedCase != 0 {
+		t.Errorf("Ord irreflexivity should have been falsified by the single value 0")
+	}
+}
+
+func TestOrdLaws(t *testing.T) {
+	Check(t, Ord(propcheck.ChooseInt(-10, 10), ltInt, eqInt))
+	Check(t, Ord(propcheck.SizedString(), func(l, r string) bool { return l < r }, func(l, r string) bool { return l == r }))
+	caseInsensitive := func(l, r string) bool { return strings.EqualFold(l, r) }
+	expectOnlyFalsified(t, Ord(propcheck.Elements("a", "A", "b"), func(l, r string) bool { return l < r }, caseInsensitive), "Ord consistency with Eq")
+	le := func(l, r int) bool { return l <= r }
+	for _, name := range []string{"Ord irreflexivity", "Ord antisymmetry", "Ord consistency with Eq"} {
+		for _, l := range Ord(propcheck.ChooseInt(-10, 10), le, eqInt) {
+			if l.Name == name && !l.Run(propcheck.RunParms{TestCases: 100, Rng: propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}}).IsFalsified() {
+				t.Errorf("%v should have been falsified for <=", name)
+			}
+		}
+	}
+}
+
+func TestEqLaws(t *testing.T) {
+	Check(t, Eq(propcheck.ChooseInt(0, 5), eqInt))
+	nearby := func(l, r int) bool { return l-r <= 1 && r-l <= 1 }
+	expectOnlyFalsified(t, Eq(propcheck.Elements(0, 1, 2), nearby), "Eq transitivity")
+}
+
+func TestFoldLaws(t *testing.T) {
+	Check(t, Fold(propcheck.SizedArray(propcheck.Int()), arrays.FoldLeft[int, []int], arrays.FoldRight[int, []int], eqInt))
+	Check(t, Fold(stack.StackOf(propcheck.Int()), stack.FoldLeft[int, []int], stack.FoldRight[int, []int], eqInt))
+	Check(t, Fold(linked_list.LinkedListOf(propcheck.Int()), linked_list.FoldLeft[int, []int], linked_list.FoldRight[int, []int], eqInt))
+	reversed := func(xs []int, z []int, f func(int, []int) []int) []int {
+		return arrays.FoldRight(arrays.Reverse(xs), z, f)
+	}
+	expectOnlyFalsified(t, Fold(propcheck.ChooseArray(2, 10, propcheck.ChooseInt(0, 100)), arrays.FoldLeft[int, []int], reversed, eqInt), "Fold consistency")
+}
@@ -0,0 +1,230 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// The largest number of commands ParallelGen puts in each branch. The linearizability check is exponential in the worst case,
+// so branches are kept short; races show up in short branches run many times rather than in long ones.
+const MaxBranchLength = 5
+
+// The number of times ParallelProp runs each test case. A race may only show up in some runs, so one passing run proves little.
+const ParallelRuns = 10
+
+/*
+*
+A test case for ParallelProp: A Prefix of commands run sequentially to put the system into an interesting state, followed by
+Branches of commands that each run sequentially in their own Go Routine at the same time as the other branches.
+*/
+type ParallelCommands[S, M any] struct {
+	Prefix   CommandSequence[S, M]
+	Branches []CommandSequence[S, M]
+}
+
+func (w ParallelCommands[S, M]) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Prefix: %v", w.Prefix)
+	for i, br := range w.Branches {
+		fmt.Fprintf(&b, ", Branch %v: %v", i+1, br)
+	}
+	return b.String()
+}
+
+// One command run by A branch of A parallel test case. Invoked and Returned are logical timestamps from A clock shared by all
+// the branches: A command that Returned before another was Invoked must be ordered before it by every linearization.
+type Operation[S, M any] struct {
+	Branch   int
+	Command  Command[S, M]
+	Result   any
+	Invoked  int64
+	Returned int64
+}
+
+func (w Operation[S, M]) String() string {
+	return fmt.Sprintf("[%v, %v] branch %v: %v returned %v", w.Invoked, w.Returned, w.Branch+1, w.Command, w.Result)
+}
+
+// A History records the Operations of each branch of A parallel test case, in the order each branch ran them.
+type History[S, M any] [][]Operation[S, M]
+
+// Lists every operation on A line of its own ordered by invocation, which shows which operations overlapped.
+func (w History[S, M]) String() string {
+	var ops []Operation[S, M]
+	for _, b := range w {
+		ops = append(ops, b...)
+	}
+	for i := 1; i < len(ops); i++ {
+		for j := i; j > 0 && ops[j].Invoked < ops[j-1].Invoked; j-- {
+			ops[j], ops[j-1] = ops[j-1], ops[j]
+		}
+	}
+	var r []string
+	for _, o := range ops {
+		r = append(r, o.String())
+	}
+	return strings.Join(r, "\n")
+}
+
+/*
+*
+Returns A Gen of ParallelCommands with A Prefix of between zero and Size commands and the given number of branches of between
+zero and MaxBranchLength commands. The Prefix is generated like StateMachine.Gen. Each branch is generated from the model state
+after the Prefix as if it ran alone, so commands in different branches may not satisfy their preconditions in every interleaving;
+such interleavings are simply not valid linearizations.
+
+The Gen shrinks by removing commands from the Prefix and the branches.
+*/
+func (sm StateMachine[S, M]) ParallelGen(branches int) Gen[ParallelCommands[S, M]] {
+	seq := sm.Gen()
+	g := genWithSize(func(rng RNG, size Size) (ParallelCommands[S, M], RNG) {
+		var r ParallelCommands[S, M]
+		r.Prefix, rng = seq.run(rng, size)
+		after := sm.InitialModel()
+		for _, c := range r.Prefix {
+			after = c.NextState(after)
+		}
+		branchSm := sm
+		branchSm.InitialModel = func() M { return after }
+		branch := branchSm.Gen()
+		for x := 0; x < branches; x++ {
+			var b CommandSequence[S, M]
+			b, rng = branch.run(rng, min(size, MaxBranchLength))
+			r.Branches = append(r.Branches, b)
+		}
+		return r, rng
+	})
+	return g.WithShrinker(func(p ParallelCommands[S, M]) []ParallelCommands[S, M] {
+		var r []ParallelCommands[S, M]
+		for _, b := range ShrinkArray[Command[S, M]](nil)(p.Prefix) {
+			if sm.valid(b) {
+				r = append(r, ParallelCommands[S, M]{Prefix: b, Branches: p.Branches})
+			}
+		}
+		for i, br := range p.Branches {
+			for _, b := range ShrinkArray[Command[S, M]](nil)(br) {
+				branches := append([]CommandSequence[S, M]{}, p.Branches...)
+				branches[i] = b
+				r = append(r, ParallelCommands[S, M]{Prefix: p.Prefix, Branches: branches})
+			}
+		}
+		return r
+	})
+}
+
+// Runs the Prefix against A new system, then runs the branches in parallel and records their History. Returns an error if
+// A postcondition of the Prefix fails.
+func (sm StateMachine[S, M]) runParallel(p ParallelCommands[S, M]) (M, History[S, M], error) {
+	s := sm.NewSystem()
+	m := sm.InitialModel()
+	for i, c := range p.Prefix {
+		result := c.Run(s)
+		if err := c.PostCondition(m, result); err != nil {
+			return m, nil, fmt.Errorf("prefix command %v %v returned %v: %w", i+1, c, result, err)
+		}
+		m = c.NextState(m)
+	}
+	var clock int64
+	history := make(History[S, M], len(p.Branches))
+	panics := make([]error, len(p.Branches))
+	var wg sync.WaitGroup
+	for i, br := range p.Branches {
+		wg.Add(1)
+		go func(i int, br CommandSequence[S, M]) {
+			defer wg.Done()
+			defer recoverPanic(&panics[i])
+			for _, c := range br {
+				o := Operation[S, M]{Branch: i, Command: c, Invoked: atomic.AddInt64(&clock, 1)}
+				o.Result = c.Run(s)
+				o.Returned = atomic.AddInt64(&clock, 1)
+				history[i] = append(history[i], o)
+			}
+		}(i, br)
+	}
+	wg.Wait()
+	for _, err := range panics {
+		if err != nil {
+			return m, history, err
+		}
+	}
+	return m, history, nil
+}
+
+/*
+*
+Whether the History is linearizable from model state m: whether there is A sequential order of all its operations that respects
+the order of each branch and the real-time order of the operations, in which every precondition and postcondition holds.
+
+This is the search of Wing and Gong, "Testing and Verifying Concurrent Objects", with the states already visited remembered by
+the printed model state so that commuting operations are not explored twice.
+*/
+func Linearizable[S, M any](m M, h History[S, M]) bool {
+	visited := map[string]bool{}
+	next := make([]int, len(h)) //The index of the first operation of each branch that is not linearized yet.
+	var search func(m M) bool
+	search = func(m M) bool {
+		key := fmt.Sprintf("%v %v", next, m)
+		if visited[key] {
+			return false
+		}
+		visited[key] = true
+		done := true
+		for i := range h {
+			if next[i] == len(h[i]) {
+				continue
+			}
+			done = false
+			o := h[i][next[i]]
+			if !minimal(h, next, o) || !o.Command.PreCondition(m) || o.Command.PostCondition(m, o.Result) != nil {
+				continue
+			}
+			next[i]++
+			ok := search(o.Command.NextState(m))
+			next[i]--
+			if ok {
+				return true
+			}
+		}
+		return done
+	}
+	return search(m)
+}
+
+// Whether no operation that is not linearized yet returned before o was invoked. Only the first such operation of each
+// branch needs checking, as it returned before the rest of its branch.
+func minimal[S, M any](h History[S, M], next []int, o Operation[S, M]) bool {
+	for i := range h {
+		if next[i] < len(h[i]) && h[i][next[i]].Returned < o.Invoked {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+*
+Returns A Prop that runs the ParallelCommands of ParallelGen(branches) against A new system, ParallelRuns times each, and is
+falsified when the History of A run is not Linearizable, i.e. when the results of the concurrently running commands cannot be
+explained by any sequential order of them. The errors of A falsified Prop include the History that was not linearizable.
+
+Use it to test that A system is safe for concurrent use, e.g. that A mutex protects every operation of A wrapper.
+*/
+func (sm StateMachine[S, M]) ParallelProp(name string, branches int) Prop {
+	return ForAll(sm.ParallelGen(branches), name,
+		func(p ParallelCommands[S, M]) error {
+			for x := 0; x < ParallelRuns; x++ {
+				m, h, err := sm.runParallel(p)
+				if err != nil {
+					return err
+				}
+				if !Linearizable(m, h) {
+					return fmt.Errorf("the history was not linearizable:\n%v", h)
+				}
+			}
+			return nil
+		},
+		func(err error) (bool, error) { return err == nil, err },
+	)
+}
@@ -0,0 +1,103 @@
+package propcheck
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A counter protected by A mutex. A racy counter releases the mutex between reading and writing, so concurrent increments can be lost.
+type sharedCounter struct {
+	mu   sync.Mutex
+	n    int
+	racy bool
+}
+
+type incrementAndGet struct{}
+
+func (incrementAndGet) PreCondition(int) bool { return true }
+func (incrementAndGet) Run(c *sharedCounter) any {
+	c.mu.Lock()
+	n := c.n
+	if c.racy {
+		c.mu.Unlock()
+		runtime.Gosched()
+		c.mu.Lock()
+	}
+	c.n = n + 1
+	c.mu.Unlock()
+	return n + 1
+}
+func (incrementAndGet) PostCondition(m int, result any) error {
+	if result != m+1 {
+		return fmt.Errorf("expected %v", m+1)
+	}
+	return nil
+}
+func (incrementAndGet) NextState(m int) int { return m + 1 }
+func (incrementAndGet) String() string      { return "incrementAndGet" }
+
+func sharedCounterMachine(racy bool) StateMachine[*sharedCounter, int] {
+	return StateMachine[*sharedCounter, int]{
+		InitialModel: func() int { return 0 },
+		NewSystem:    func() *sharedCounter { return &sharedCounter{racy: racy} },
+		Commands: func(int) Gen[Command[*sharedCounter, int]] {
+			return Id[Command[*sharedCounter, int]](incrementAndGet{})
+		},
+	}
+}
+
+func op(branch int, result int, invoked, returned int64) Operation[*sharedCounter, int] {
+	return Operation[*sharedCounter, int]{Branch: branch, Command: incrementAndGet{}, Result: result, Invoked: invoked, Returned: returned}
+}
+
+func TestLinearizable(t *testing.T) {
+	overlapping := History[*sharedCounter, int]{{op(0, 2, 1, 4)}, {op(1, 1, 2, 3)}}
+	if !Linearizable(0, overlapping) {
+		t.Errorf("%v should have been linearizable with branch 2 first", overlapping)
+	}
+	lostUpdate := History[*sharedCounter, int]{{op(0, 1, 1, 4)}, {op(1, 1, 2, 3)}}
+	if Linearizable(0, lostUpdate) {
+		t.Errorf("%v should not have been linearizable", lostUpdate)
+	}
+	realTime := History[*sharedCounter, int]{{op(0, 2, 1, 2)}, {op(1, 1, 3, 4)}}
+	if Linearizable(0, realTime) {
+		t.Errorf("%v should not have been linearizable because branch 1 returned before branch 2 was invoked", realTime)
+	}
+}
+
+func TestParallelPropPassesForLinearizableSystem(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	result := sharedCounterMachine(false).ParallelProp("A locked counter is linearizable", 3).Run(RunParms{TestCases: 50, Rng: rng})
+	ExpectSuccess[ParallelCommands[*sharedCounter, int]](t, result)
+}
+
+func TestParallelPropFalsifiesRace(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	result := sharedCounterMachine(true).ParallelProp("A racy counter is not linearizable", 2).Run(RunParms{TestCases: 100, Rng: rng})
+	f, ok := result.(Falsified[ParallelCommands[*sharedCounter, int]])
+	if !ok {
+		t.Fatalf("Property should have been falsified but was %v", result)
+	}
+	if !strings.Contains(f.Errors.Error(), "the history was not linearizable") {
+		t.Errorf("Errors should have reported the history but were %v", f.Errors)
+	}
+}
+
+func TestParallelGenShrinksBranches(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := sharedCounterMachine(false).ParallelGen(2)
+	for _, p := range g.Sample(rng, 20) {
+		if len(p.Branches) != 2 || len(p.Branches[0]) > MaxBranchLength || len(p.Branches[1]) > MaxBranchLength {
+			t.Errorf("%v should have had two branches of at most %v commands", p, MaxBranchLength)
+		}
+		for _, c := range g.Shrinker()(p) {
+			if len(c.Prefix)+len(c.Branches[0])+len(c.Branches[1]) >= len(p.Prefix)+len(p.Branches[0])+len(p.Branches[1]) {
+				t.Errorf("Shrink candidate %v should have had fewer commands than %v", c, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package propcheck
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// The signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// The unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// All the integer types.
+type Integral interface {
+	Signed | Unsigned
+}
+
+// The floating point types.
+type Floating interface {
+	~float32 | ~float64
+}
+
+// The numeric generators draw one value in EdgeCaseOdds from the edge cases of their range, such as zero, one, minus one and
+// the bounds of the range, because that is where arithmetic and serialization code breaks.
+const EdgeCaseOdds = 8
+
+// The smallest and largest values of an integer type.
+func integerBounds[T Integral]() (T, T) {
+	var zero T
+	if zero-1 > zero {
+		return zero, ^zero
+	}
+	min := T(1)
+	for min > 0 { //Shifts the one bit up to the sign bit.
+		min <<= 1
+	}
+	return min, ^min
+}
+
+// Generates A value of type T from its whole range, with edge cases such as 0, 1, -1 and the smallest and largest values of T
+// drawn one time in EdgeCaseOdds. The Gen shrinks toward zero.
+func Integer[T Integral]() Gen[T] {
+	lo, hi := integerBounds[T]()
+	return ChooseIntegerInclusive(lo, hi)
+}
+
+// Generates an integer between start and stop exclusive. See ChooseIntegerInclusive.
+func ChooseInteger[T Integral](start, stopExclusive T) Gen[T] {
+	if stopExclusive <= start {
+		panic(fmt.Sprintf("ChooseInteger needs start < stopExclusive but the range was [%v, %v)", start, stopExclusive))
+	}
+	return ChooseIntegerInclusive(start, stopExclusive-1)
+}
+
+// Generates an integer between start and stop inclusive, with edge cases such as the bounds, 0, 1 and -1 drawn one time in
+// EdgeCaseOdds when they are in range. The Gen shrinks toward the value in the range that is closest to zero.
+func ChooseIntegerInclusive[T Integral](start, stopInclusive T) Gen[T] {
+	if stopInclusive < start {
+		panic(fmt.Sprintf("ChooseIntegerInclusive needs start <= stopInclusive but the range was [%v, %v]", start, stopInclusive))
+	}
+	target := start
+	if start < 0 && stopInclusive >= 0 {
+		target = 0
+	} else if stopInclusive < 0 {
+		target = stopInclusive
+	}
+	var edges []T
+	for _, e := range []T{target, start, stopInclusive, start + 1, stopInclusive - 1, target + 1, target - 1} {
+		if e >= start && e <= stopInclusive && !contains(edges, e) {
+			edges = append(edges, e)
+		}
+	}
+	span := uint64(stopInclusive) - uint64(start) //Two's complement arithmetic gives the span of signed and unsigned ranges alike.
+	g := NewGen(func(rng RNG) (T, RNG) {
+		if e, r, ok := edgeCase(rng, edges); ok {
+			return e, r
+		}
+		u, r := uint64n(rng, span)
+		return start + T(u), r
+	})
+	return g.WithShrinker(func(x T) []T {
+		var r []T
+		for d := x - target; d != 0; d = d / 2 {
+			if c := x - d; !contains(r, c) {
+				r = append(r, c)
+			}
+		}
+		return r
+	})
+}
+
+// Draws one of the edges one time in EdgeCaseOdds.
+func edgeCase[T any](rng RNG, edges []T) (T, RNG, bool) {
+	var zero T
+	i, r := rng.Intn(EdgeCaseOdds)
+	if i != 0 || len(edges) == 0 {
+		return zero, r, false
+	}
+	i, r = r.Intn(len(edges))
+	return edges[i], r, true
+}
+
+// A uniformly random uint64 in [0, max].
+func uint64n(rng RNG, max uint64) (uint64, RNG) {
+	if max == math.MaxUint64 {
+		return rng.Uint64()
+	}
+	bound := max + 1
+	threshold := -bound % bound
+	for {
+		var u uint64
+		u, rng = rng.Uint64()
+		hi, lo := bits.Mul64(u, bound)
+		if lo >= threshold {
+			return hi, rng
+		}
+	}
+}
+
+func contains[T comparable](xs []T, x T) bool {
+	for _, y := range xs {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+// Generates an int8 from its whole range. See Integer.
+func Int8() Gen[int8] { return Integer[int8]() }
+
+// Generates an int16 from its whole range. See Integer.
+func Int16() Gen[int16] { return Integer[int16]() }
+
+// Generates an int32 from its whole range. See Integer.
+func Int32() Gen[int32] { return Integer[int32]() }
+
+// Generates an int64 from its whole range. See Integer.
+func Int64() Gen[int64] { return Integer[int64]() }
+
+// Generates A uint from its whole range. See Integer.
+func Uint() Gen[uint] { return Integer[uint]() }
+
+// Generates A uint8 from its whole range. See Integer.
+func Uint8() Gen[uint8] { return Integer[uint8]() }
+
+// Generates A uint16 from its whole range. See Integer.
+func Uint16() Gen[uint16] { return Integer[uint16]() }
+
+// Generates A uint32 from its whole range. See Integer.
+func Uint32() Gen[uint32] { return Integer[uint32]() }
+
+// Generates A uint64 from its whole range. See Integer.
+func Uint64() Gen[uint64] { return Integer[uint64]() }
+
+// The special values of A floating point type: ±0, ±1, ±Inf, NaN, the smallest subnormals and the largest finite values.
+func floatSpecials[T Floating]() []T {
+	smallest, largest := math.SmallestNonzeroFloat64, math.MaxFloat64
+	if bits32[T]() {
+		smallest, largest = math.SmallestNonzeroFloat32, math.MaxFloat32
+	}
+	return []T{0, T(math.Copysign(0, -1)), 1, -1, T(math.Inf(1)), T(math.Inf(-1)), T(math.NaN()),
+		T(smallest), T(-smallest), T(largest), T(-largest)}
+}
+
+// Whether T is A 32-bit floating point type.
+func bits32[T Floating]() bool {
+	x := T(math.MaxFloat32)
+	return float64(x*2) == math.Inf(1)
+}
+
+/*
+*
+Generates A value of type T from its whole range: every bit pattern is equally likely, so values of every magnitude, subnormals,
+infinities and NaNs all occur. One time in EdgeCaseOdds it draws A special value instead: ±0, ±1, ±Inf, NaN, the smallest
+positive and negative subnormals and the largest finite values.
+
+The Gen shrinks toward zero, whole numbers and smaller magnitudes. Use SuchThat or Filter with math.IsNaN and math.IsInf
+to exclude the non-finite values.
+*/
+func AnyFloat[T Floating]() Gen[T] {
+	specials := floatSpecials[T]()
+	g := NewGen(func(rng RNG) (T, RNG) {
+		if e, r, ok := edgeCase(rng, specials); ok {
+			return e, r
+		}
+		u, r := rng.Uint64()
+		if bits32[T]() {
+			return T(math.Float32frombits(uint32(u))), r
+		}
+		return T(math.Float64frombits(u)), r
+	})
+	return g.WithShrinker(shrinkFloat(T(math.Inf(-1)), T(math.Inf(1))))
+}
+
+// Generates A float32 from its whole range. See AnyFloat.
+func Float32() Gen[float32] { return AnyFloat[float32]() }
+
+// Generates A float64 from its whole range. See AnyFloat.
+func Float64() Gen[float64] { return AnyFloat[float64]() }
+
+// Generates A floating point number uniformly between start and stop exclusive. See ChooseFloatInclusive.
+func ChooseFloat[T Floating](start, stopExclusive T) Gen[T] {
+	if !(start < stopExclusive) {
+		panic(fmt.Sprintf("ChooseFloat needs start < stopExclusive but the range was [%v, %v)", start, stopExclusive))
+	}
+	return chooseFloat(start, stopExclusive, false)
+}
+
+// Generates A floating point number uniformly between start and stop inclusive, with the bounds, 0, 1 and -1 drawn one time in
+// EdgeCaseOdds when they are in range. The bounds must be finite. The Gen shrinks toward the value in the range that is closest to zero.
+func ChooseFloatInclusive[T Floating](start, stopInclusive T) Gen[T] {
+	if !(start <= stopInclusive) {
+		panic(fmt.Sprintf("ChooseFloatInclusive needs start <= stopInclusive but the range was [%v, %v]", start, stopInclusive))
+	}
+	return chooseFloat(start, stopInclusive, true)
+}
+
+func chooseFloat[T Floating](start, stop T, inclusive bool) Gen[T] {
+	if math.IsInf(float64(start), 0) || math.IsInf(float64(stop), 0) {
+		panic(fmt.Sprintf("The range [%v, %v] of A float Gen must be finite", start, stop))
+	}
+	inRange := func(x T) bool {
+		return x >= start && (x < stop || inclusive && x == stop)
+	}
+	var edges []T
+	for _, e := range []T{0, start, stop, 1, -1} {
+		if inRange(e) && !contains(edges, e) {
+			edges = append(edges, e)
+		}
+	}
+	g := NewGen(func(rng RNG) (T, RNG) {
+		if e, r, ok := edgeCase(rng, edges); ok {
+			return e, r
+		}
+		u, r := rng.Uint64()
+		unit := float64(u>>11) / (1 << 53) //Uniform in [0, 1).
+		x := T(float64(start)*(1-unit) + float64(stop)*unit) //Unlike start + unit*(stop-start), does not overflow for A range wider than the largest float.
+		if !inRange(x) { //Rounding can land on stop.
+			x = start
+		}
+		return x, r
+	})
+	return g.WithShrinker(filterShrinker(shrinkFloat(start, stop), inRange))
+}
+
+// Shrinks A float toward the value in [lo, hi] closest to zero by trying that value, truncating, halving its exponent and halving.
+// NaN shrinks to that value and the infinities to that value or the largest finite value of the same sign.
+func shrinkFloat[T Floating](lo, hi T) Shrinker[T] {
+	target := T(0)
+	if lo > 0 {
+		target = lo
+	} else if hi < 0 {
+		target = hi
+	}
+	return func(x T) []T {
+		if x == target {
+			return nil
+		}
+		if x != x {
+			return []T{target}
+		}
+		if math.IsInf(float64(x), 0) {
+			largest := math.MaxFloat64
+			if bits32[T]() {
+				largest = math.MaxFloat32
+			}
+			return filterShrinker(func(T) []T { return []T{target, T(math.Copysign(largest, float64(x)))} }, func(c T) bool { return c >= lo && c <= hi })(x)
+		}
+		//Halving the exponent takes A huge value down to A reasonable one in A few steps, where halving the value would take hundreds.
+		frac, exp := math.Frexp(float64(x - target))
+		var r []T
+		for _, c := range []T{target, T(math.Trunc(float64(x))), target + T(math.Ldexp(frac, exp/2)), target + (x-target)/2} {
+			if c != x && c >= lo && c <= hi && math.Abs(float64(c-target)) < math.Abs(float64(x-target)) && !contains(r, c) {
+				r = append(r, c)
+			}
+		}
+		return r
+	}
+}
@@ -0,0 +1,173 @@
+package propcheck
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIntegerBounds(t *testing.T) {
+	if lo, hi := integerBounds[int8](); lo != math.MinInt8 || hi != math.MaxInt8 {
+		t.Errorf("int8 bounds should have been [%v, %v] but were [%v, %v]", math.MinInt8, math.MaxInt8, lo, hi)
+	}
+	if lo, hi := integerBounds[int64](); lo != math.MinInt64 || hi != math.MaxInt64 {
+		t.Errorf("int64 bounds should have been [%v, %v] but were [%v, %v]", int64(math.MinInt64), int64(math.MaxInt64), lo, hi)
+	}
+	if lo, hi := integerBounds[uint16](); lo != 0 || hi != math.MaxUint16 {
+		t.Errorf("uint16 bounds should have been [0, %v] but were [%v, %v]", math.MaxUint16, lo, hi)
+	}
+}
+
+func TestIntegerProducesEdgeCases(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	seen := map[int64]bool{}
+	for _, x := range Int64().Sample(rng, 1000) {
+		seen[x] = true
+	}
+	for _, e := range []int64{0, 1, -1, math.MinInt64, math.MaxInt64} {
+		if !seen[e] {
+			t.Errorf("Int64 should have produced the edge case %v", e)
+		}
+	}
+	negative := 0
+	for x := range seen {
+		if x < -1000000 {
+			negative++
+		}
+	}
+	if negative == 0 {
+		t.Errorf("Int64 should have produced large negative numbers")
+	}
+}
+
+func TestChooseIntegerStaysInRange(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, x := range ChooseInteger[int8](-5, 5).Sample(rng, 500) {
+		if x < -5 || x >= 5 {
+			t.Errorf("%v was outside [-5, 5)", x)
+		}
+	}
+	seen := map[uint8]bool{}
+	for _, x := range ChooseIntegerInclusive[uint8](250, 255).Sample(rng, 500) {
+		if x < 250 {
+			t.Errorf("%v was outside [250, 255]", x)
+		}
+		seen[x] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("Every value in [250, 255] should have been produced but only %v were", seen)
+	}
+}
+
+func TestChooseIntegerShrinksTowardZero(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(Int32(), "Numbers are greater than -1000",
+		func(x int32) int32 { return x },
+		func(x int32) (bool, error) {
+			if x <= -1000 {
+				return false, fmt.Errorf("%v was too small", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[int32](t, result)
+	if f := result.(Falsified[int32]); f.FailedCase != -1000 {
+		t.Errorf("The counterexample should have shrunk to -1000 but was %v", f.FailedCase)
+	}
+	for _, c := range ChooseIntegerInclusive[uint](10, 20).Shrinker()(17) {
+		if c < 10 || c >= 17 {
+			t.Errorf("Shrink candidate %v should have been in [10, 17)", c)
+		}
+	}
+}
+
+func TestAnyFloatProducesSpecialValues(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var nan, posInf, negInf, negZero, subnormal, huge, negative int
+	for _, x := range Float64().Sample(rng, 2000) {
+		switch {
+		case math.IsNaN(x):
+			nan++
+		case math.IsInf(x, 1):
+			posInf++
+		case math.IsInf(x, -1):
+			negInf++
+		case x == 0 && math.Signbit(x):
+			negZero++
+		case x != 0 && math.Abs(x) < 0x1p-1022:
+			subnormal++
+		case math.Abs(x) > 1e100:
+			huge++
+		}
+		if x < 0 {
+			negative++
+		}
+	}
+	if nan == 0 || posInf == 0 || negInf == 0 || negZero == 0 || subnormal == 0 || huge == 0 || negative == 0 {
+		t.Errorf("Float64 should have produced every kind of value but produced %v NaN, %v +Inf, %v -Inf, %v -0, %v subnormal, %v huge and %v negative",
+			nan, posInf, negInf, negZero, subnormal, huge, negative)
+	}
+	for _, x := range Float32().Sample(rng, 200) {
+		if !math.IsNaN(float64(x)) && !math.IsInf(float64(x), 0) && math.Abs(float64(x)) > math.MaxFloat32 {
+			t.Errorf("%v was not a float32", x)
+		}
+	}
+}
+
+func TestChooseFloatStaysInRange(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var ones int
+	for _, x := range ChooseFloat(-2.5, 1.0).Sample(rng, 1000) {
+		if x < -2.5 || x >= 1 {
+			t.Errorf("%v was outside [-2.5, 1)", x)
+		}
+	}
+	for _, x := range ChooseFloatInclusive[float32](0.5, 1).Sample(rng, 1000) {
+		if x < 0.5 || x > 1 {
+			t.Errorf("%v was outside [0.5, 1]", x)
+		}
+		if x == 1 {
+			ones++
+		}
+	}
+	if ones == 0 {
+		t.Errorf("ChooseFloatInclusive should have produced its upper bound")
+	}
+}
+
+func TestChooseFloatSpreadsOverWholeRange(t *testing.T) {
+	var negatives, positives int
+	for _, x := range ChooseFloat(-math.MaxFloat64, math.MaxFloat64).Sample(SplitMix64{State: 3}, 1000) {
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			t.Fatalf("%v was not finite", x)
+		}
+		if x < 0 && x != -math.MaxFloat64 {
+			negatives++
+		} else if x > 0 {
+			positives++
+		}
+	}
+	if negatives < 100 || positives < 100 {
+		t.Errorf("There should have been values of both signs other than start but there were %v negative and %v positive", negatives, positives)
+	}
+}
+
+func TestFloatShrinksTowardZero(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(Float64(), "Floats are small or NaN",
+		func(x float64) float64 { return x },
+		func(x float64) (bool, error) {
+			if math.Abs(x) >= 10 {
+				return false, fmt.Errorf("%v was not small", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[float64](t, result)
+	if f := result.(Falsified[float64]); math.Abs(f.FailedCase) >= 20 || math.IsNaN(f.FailedCase) {
+		t.Errorf("The counterexample should have shrunk to a finite number just above 10 but was %v", f.FailedCase)
+	}
+}
@@ -0,0 +1,30 @@
+package propcheck
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// A PanicError is the error ForAll records when A generator, the transformation function or an assertion panics.
+// It carries the value passed to panic and the stack trace of the panicking Go Routine.
+type PanicError struct {
+	Value any
+	Stack string
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Turns A panic into A PanicError assigned to *err, or into ErrDiscard if A SuchThat Gen discarded the test case.
+// It must be deferred directly, i.e. "defer recoverPanic(&err)".
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		if e, ok := r.(error); ok && errors.Is(e, ErrDiscard) {
+			*err = ErrDiscard
+			return
+		}
+		*err = PanicError{Value: r, Stack: string(debug.Stack())}
+	}
+}
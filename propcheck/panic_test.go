@@ -0,0 +1,106 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPanicInAssertionFalsifiesAndShrinks(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseInt(0, 100000), "Assertion panics for large numbers.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x >= 100 {
+				panic(fmt.Sprintf("%v is too large", x))
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[int]:
+		if v.FailedCase != 100 {
+			t.Errorf("Expected the panicking input to shrink to 100 but was %v", v.FailedCase)
+		}
+		if v.Panic != "100 is too large" {
+			t.Errorf("Expected the panic value of the shrunk input but was %v", v.Panic)
+		}
+		if !strings.Contains(v.Stack, "panic_test.go") {
+			t.Errorf("Expected the stack trace to include the panicking assertion but was %v", v.Stack)
+		}
+		if v.Seed != rng {
+			t.Errorf("Expected the seed %v but was %v", rng, v.Seed)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestPanicInTransformationFalsifies(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseArray(0, 10, Int()), "Transformation indexes past the end of the array.",
+		func(xs []int) int { return xs[10] },
+		func(x int) (bool, error) {
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[[]int]:
+		if len(v.FailedCase) != 0 {
+			t.Errorf("Expected the panicking input to shrink to the empty array but was %v", v.FailedCase)
+		}
+		if v.Panic == nil {
+			t.Errorf("Expected a panic to be recorded")
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestPanicInGeneratorFalsifies(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := Map(ChooseInt(0, 10), func(x int) int {
+		if x == 5 {
+			panic("the generator cannot make a 5")
+		}
+		return x
+	})
+	prop := ForAll(ge, "Generator panics.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[int]:
+		if v.Panic != "the generator cannot make a 5" {
+			t.Errorf("Expected the panic from the generator but was %v", v.Panic)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestPanicInShrinkerStopsShrinking(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := ChooseInt(100, 1000).WithShrinker(func(x int) []int { panic("bad shrinker") })
+	prop := ForAll(ge, "Always fails.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			return false, fmt.Errorf("%v failed", x)
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 10, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[int]:
+		if v.Shrinks != 0 || v.Panic != nil {
+			t.Errorf("A panicking Shrinker should have stopped shrinking without falsifying the property with a panic but was %v", v)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
@@ -0,0 +1,37 @@
+package propcheck
+
+import "sync"
+
+// The outcome of A single test case in ForAll.
+type testCase[A any] struct {
+	input     A
+	err       error
+	generated bool //False if the generator panicked, in which case there is no input.
+}
+
+// Calls f for every test case index in [0, n) using the given number of Go Routines and waits for all of them to finish.
+// Each index is passed to f exactly once, so f may write to its own element of A slice without further synchronization.
+func forEachCase(n, workers int, f func(x int)) {
+	if workers <= 1 {
+		for x := 0; x < n; x++ {
+			f(x)
+		}
+		return
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for x := range indexes {
+				f(x)
+			}
+		}()
+	}
+	for x := 0; x < n; x++ {
+		indexes <- x
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package propcheck
+
+import (
+	"fmt"
+	"github.com/go-test/deep"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkersReportTheSameFailureAsSequentialRun(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseArray(0, 20, ChooseInt(0, 1000)), "Arrays must not contain numbers over 900.",
+		func(xs []int) []int {
+			time.Sleep(time.Microsecond * time.Duration(len(xs)))
+			return xs
+		},
+		func(xs []int) (bool, error) {
+			for _, x := range xs {
+				if x > 900 {
+					return false, fmt.Errorf("%v was over 900", x)
+				}
+			}
+			return true, nil
+		},
+	)
+	sequential := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	for _, workers := range []int{2, 8, 32} {
+		parallel := prop.Run(RunParms{TestCases: 200, Rng: rng, Workers: workers})
+		l, lok := sequential.(Falsified[[]int])
+		r, rok := parallel.(Falsified[[]int])
+		if !lok || !rok {
+			t.Fatalf("Both runs should have been falsified but were %v and %v", sequential, parallel)
+		}
+		if l.Successes != r.Successes {
+			t.Errorf("With %v workers the failure should have been at test case %v but was at %v", workers, l.Successes, r.Successes)
+		}
+		if diff := deep.Equal(l.FailedCase, r.FailedCase); diff != nil {
+			t.Error(diff)
+		}
+		if diff := deep.Equal(l.LastSuccessCase, r.LastSuccessCase); diff != nil {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestWorkersRunConcurrently(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var running, most int32
+	prop := ForAll(Int(), "Test cases overlap.",
+		func(x int) int {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&most)
+				if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return x
+		},
+		func(x int) (bool, error) {
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng, Workers: 4})
+	ExpectSuccess[int](t, result)
+	if most < 2 {
+		t.Errorf("Expected test cases to run concurrently but at most %v ran at once", most)
+	}
+}
+
+func TestForEachCaseVisitsEveryIndexOnce(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		visits := make([]int32, 50)
+		forEachCase(len(visits), workers, func(x int) {
+			atomic.AddInt32(&visits[x], 1)
+		})
+		for x, v := range visits {
+			if v != 1 {
+				t.Errorf("With %v workers index %v was visited %v times", workers, x, v)
+			}
+		}
+	}
+}
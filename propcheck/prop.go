@@ -1,9 +1,10 @@
 package propcheck
 
 import (
+	"errors"
 	"fmt"
 	"github.com/hashicorp/go-multierror"
-	"log"
+	"strings"
 	"testing"
 )
 
@@ -13,7 +14,11 @@ type Run = func(RunParms) Result
 
 type RunParms struct {
 	TestCases TestCases
-	Rng       SimpleRNG
+	Rng       RNG  //Defaults to SimpleRNG{Seed: 0} when nil.
+	MaxSize   Size //The Size given to generators for the last test case. Defaults to DefaultMaxSize when zero.
+	Workers   int  //The number of Go Routines that evaluate test cases. Defaults to 1 when zero. See ForAll.
+	//The number of discarded test cases allowed per test case before ForAll gives up. Defaults to DefaultMaxDiscardRatio when zero.
+	MaxDiscardRatio int
 }
 type Result interface {
 	IsFalsified() bool
@@ -34,19 +39,33 @@ type Falsified[A any] struct {
 	Successes       int
 	LastSuccessCase A
 	Errors          error
-	Seed            SimpleRNG
+	Seed            RNG
+	Shrinks         int
+	Discards        int
+	Panic           any    //The value passed to panic if the FailedCase caused a panic, otherwise nil.
+	Stack           string //The stack trace of the panic if there was one.
 }
 
 func (w Falsified[A]) String() string {
-	return fmt.Sprintf("\u001B[31m Falsified{Seed: %v, Name: %v, FailedCase: %v, Successes: %v, LastSuccessCase: %v, Errors: %v \u001B[30m}", w.Seed, w.Name, w.FailedCase, w.Successes, w.LastSuccessCase, w.Errors)
+	var p string
+	if w.Panic != nil {
+		p = fmt.Sprintf(", Panic: %v", w.Panic)
+	}
+	return colorize(red, fmt.Sprintf("Falsified{Seed: %v, Name: %v, FailedCase: %v, Shrinks: %v, Successes: %v, LastSuccessCase: %v, Errors: %v%v, Replay: %v}", w.Seed, w.Name, w.FailedCase, w.Shrinks, w.Successes, w.LastSuccessCase, w.Errors, p, ReplayCommand(w.Seed)))
 }
 
 type Passed[A any] struct {
-	Seed SimpleRNG
+	Seed      RNG
+	TestCases int
+	Labels    map[string]int //The number of test cases given each label by Classify, Collect and Cover.
+	Discards  int            //The number of test cases discarded by SuchThat or Implies.
 }
 
 func (w Passed[A]) String() string {
-	return fmt.Sprintf("Passed{%v}", w.Seed)
+	if len(w.Labels) == 0 {
+		return fmt.Sprintf("Passed{%v}", w.Seed)
+	}
+	return fmt.Sprintf("Passed{%v, %v}", w.Seed, strings.Join(histogram(w.Labels, w.TestCases), ", "))
 }
 
 func (f Falsified[A]) IsFalsified() bool {
@@ -90,7 +109,8 @@ ForAll produces a function(of type Prop) that will run a set number of test case
 
 Parameters:
 
-	ge - a generator of type "func(SimpleRNG) (A, SimpleRNG)"
+	ge - a generator of type "Gen[A]". If the Gen has a Shrinker, a failing value is repeatedly replaced with the first of its shrink
+	     candidates that still fails, so the FailedCase is a locally minimal counterexample. Falsified.Shrinks records the number of shrink steps taken.
 	name - a name to assign the Prop
 	f - a function of type "f func(A) B" that takes the generated type A and returns another type B and then passes it along to the list of assertion functions.
 	assertions - a variadic list of assertion functions of type "func(B) (bool, error)", each returning a pair consisting of a boolean success and a possible list of errors.
@@ -100,58 +120,127 @@ Returns:
 	    Prop - a data structure consisting of a descriptive name for the property and a function of type func(n RunParms) Result. Result is a sum type that can be
 			either Falsified or Passed. The FailedCase and LastSuccessCase attributes of the Falsified type(type parameter A)
 	        contain the value that caused the test failure and the last successful value for the test.
+
+A test case is discarded rather than run when a SuchThat Gen cannot draw an input that satisfies its predicate or an assertion returns ErrDiscard, see Implies.
+Discarded test cases are replaced by new ones until RunParms.TestCases have run, unless more than RunParms.MaxDiscardRatio
+were discarded per test case, in which case ForAll gives up and returns GaveUp.
+
+A panic in the generator, in f or in an assertion falsifies the property. The Falsified result records the value passed to panic,
+its stack trace and the input that caused it. If the generator itself panicked there is no input and FailedCase is the zero value of A.
+
+When RunParms.Workers is greater than one, f and the assertions are called from that many Go Routines at once and must be safe
+for concurrent use. Test data is still generated sequentially, so the same seed produces the same test cases, and the reported
+failure is always the one with the lowest test case index, no matter how many Workers there are. Shrinking is sequential.
 */
-func ForAll[A, B any](ge func(SimpleRNG) (A, SimpleRNG), name string, f func(A) B, assertions ...func(B) (bool, error)) Prop {
-	var origRng SimpleRNG
-	run := func(n RunParms) Result {
-		defer func() {
-			if err := recover(); err != nil {
-				log.Printf("Panic:%v occurred in test:%v for seed:%v:", err, name, origRng)
+func ForAll[A, B any](ge Gen[A], name string, f func(A) B, assertions ...func(B) (bool, error)) Prop {
+	check := func(a A) (errors error) {
+		defer recoverPanic(&errors)
+		b := f(a)
+		for _, s := range assertions {
+			success, err := s(b)
+			if !success {
+				if isDiscard(err) {
+					return ErrDiscard
+				}
+				if err != nil {
+					errors = multierror.Append(errors, err)
+				}
+				break
 			}
-		}()
+		}
+		return errors
+	}
+	//A discarded shrink candidate is not A counterexample.
+	checkShrink := func(a A) error {
+		if err := check(a); !isDiscard(err) {
+			return err
+		}
+		return nil
+	}
+	generate := func(rng RNG, size Size) (a A, next RNG, err error) {
+		defer recoverPanic(&err)
+		a, next = ge.run(rng, size)
+		return a, next, nil
+	}
+	run := func(n RunParms) Result {
 		var rng = n.Rng
-		if origRng.Seed == 0 { //Original seed not initialized for test failure and panic/error reporting
-			origRng = rng
+		if rng == nil {
+			rng = SimpleRNG{}
 		}
-		var failedCases []Falsified[A]
-		var successCases []Result
-		var lastSuccessCase A
-		var testData A
-		for x := 0; x < n.TestCases; x++ {
-			testData, rng = ge(rng)
-			b := f(testData)
-			var errors error
-			for _, s := range assertions {
-				success, err := s(b)
-				if !success {
-					if err != nil {
-						errors = multierror.Append(errors, err)
-					}
-					break
+		origRng := rng //Kept for test failure and panic/error reporting
+		//Test data is always generated sequentially so that the same seed produces the same test cases no matter how many Workers there are.
+		//Replacements for discarded test cases are generated in further batches until enough test cases have run or there were too many discards.
+		var cases []testCase[A]
+		ran, discards, failed := 0, 0, false
+		for ran < n.TestCases && discards <= maxDiscards(n) && !failed {
+			batch := make([]testCase[A], n.TestCases-ran)
+			for x := range batch {
+				testData, next, err := generate(rng, sizeFor(ran+x, n.TestCases, n.MaxSize))
+				if err == nil {
+					rng = next
 				}
+				batch[x] = testCase[A]{input: testData, err: err, generated: err == nil}
+				_, rng = rng.NextInt()
 			}
-			if errors == nil {
-				successCases = append(successCases, Passed[A]{})
-				lastSuccessCase = testData
-			} else {
-				f := Falsified[A]{
-					Name:            name,
-					FailedCase:      testData,
-					Successes:       x,
-					LastSuccessCase: lastSuccessCase,
-					Errors:          errors,
-					Seed:            origRng,
+			forEachCase(len(batch), n.Workers, func(x int) {
+				if batch[x].generated {
+					batch[x].err = check(batch[x].input)
+				}
+			})
+			for _, c := range batch {
+				if isDiscard(c.err) {
+					discards++
+				} else {
+					ran++
+					failed = failed || c.err != nil
 				}
-				failedCases = append(failedCases, f)
 			}
-			_, rng = NextInt(rng)
+			cases = append(cases, batch...)
 		}
-		if len(failedCases) > 0 {
-			return failedCases[0]
-		} else {
-			return Passed[A]{origRng}
+		var lastSuccessCase A
+		successes := 0
+		for _, c := range cases {
+			if isDiscard(c.err) {
+				continue
+			}
+			if c.err == nil {
+				lastSuccessCase = c.input
+				successes++
+				continue
+			}
+			r := Falsified[A]{
+				Name:            name,
+				FailedCase:      c.input,
+				Successes:       successes,
+				LastSuccessCase: lastSuccessCase,
+				Errors:          c.err,
+				Seed:            origRng,
+				Discards:        discards,
+			}
+			if c.generated {
+				r.FailedCase, r.Errors, r.Shrinks = shrink(r.FailedCase, r.Errors, ge.shrink, checkShrink)
+			}
+			var p PanicError
+			if errors.As(r.Errors, &p) {
+				r.Panic, r.Stack = p.Value, p.Stack
+			}
+			return r
 		}
-
+		if ran < n.TestCases {
+			return GaveUp[A]{Name: name, Seed: origRng, Successes: successes, Discards: discards}
+		}
+		labels := classify(ge, cases)
+		if err := checkCoverage(ge, labels, ran); err != nil {
+			return Falsified[A]{
+				Name:            name,
+				Successes:       ran,
+				LastSuccessCase: lastSuccessCase,
+				Errors:          err,
+				Seed:            origRng,
+				Discards:        discards,
+			}
+		}
+		return Passed[A]{Seed: origRng, TestCases: ran, Labels: labels, Discards: discards}
 	}
 	return Prop{run, name}
 }
@@ -159,8 +248,10 @@ func ForAll[A, B any](ge func(SimpleRNG) (A, SimpleRNG), name string, f func(A)
 func ExpectSuccess[A any](t *testing.T, result Result) {
 	switch v := result.(type) {
 	case Falsified[A]:
-		t.Errorf("\033[31m Test Falsified with: %v  \u001B[30m \n", v)
+		t.Error(colorize(red, fmt.Sprintf("Test Falsified with: %v", v)))
 	case Passed[A]:
+	case GaveUp[A]:
+		t.Error(colorize(red, fmt.Sprintf("Test gave up with: %v", v)))
 	default:
 		panic(fmt.Sprintf("Expected type of Result to be:%T which is the type of the generator.", v))
 	}
@@ -169,7 +260,9 @@ func ExpectSuccess[A any](t *testing.T, result Result) {
 func ExpectFailure[A any](t *testing.T, result Result) {
 	switch v := result.(type) {
 	case Passed[A]:
-		t.Errorf("\u001B[31m Expected test to be Falsified but it was: %v \u001B[30m \n", v)
+		t.Error(colorize(red, fmt.Sprintf("Expected test to be Falsified but it was: %v", v)))
+	case GaveUp[A]:
+		t.Error(colorize(red, fmt.Sprintf("Expected test to be Falsified but it gave up: %v", v)))
 	case Falsified[A]:
 	default:
 		panic(fmt.Sprintf("Expected type of Result to be:%T which is the type of the generator.", v))
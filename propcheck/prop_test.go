@@ -27,7 +27,7 @@ func TestAnd(t *testing.T) {
 		Run:  f2,
 		Name: "first properties test",
 	}
-	actual := And[string](p1, p2).Run(RunParms{200, rng})
+	actual := And[string](p1, p2).Run(RunParms{TestCases: 200, Rng: rng})
 	switch v := actual.(type) {
 	case Passed[string]:
 		t.Errorf("Invoking And with one Falsified and one Passed Result should have been Falsified and was %v \n", v)
@@ -55,7 +55,7 @@ func TestOr(t *testing.T) {
 		Run:  f2,
 		Name: "first properties test",
 	}
-	actual := Or[string](p1, p2).Run(RunParms{200, rng})
+	actual := Or[string](p1, p2).Run(RunParms{TestCases: 200, Rng: rng})
 	switch v := actual.(type) {
 	case Falsified[string]:
 		t.Errorf("Invoking Or with one Falsified and one Passed Result should have been Passed and was %v \n", v)
@@ -76,7 +76,7 @@ func TestForAllWithInts(t *testing.T) {
 			}
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[int](t, result)
 }
 
@@ -94,7 +94,7 @@ func TestForAllWithArrayOfArraysThatFails(t *testing.T) {
 			}
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectFailure[[]int](t, result)
 }
 
@@ -107,7 +107,7 @@ func TestExpectFailure(t *testing.T) {
 			return false, fmt.Errorf("a test failure: %v", xs)
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectFailure[int](t, result)
 }
 
@@ -124,7 +124,7 @@ func TestFailureLogsCorrectSeed(t *testing.T) {
 			}
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	if !strings.Contains(fmt.Sprintf("%v", result), fmt.Sprintf("%v", rng)) {
 		t.Errorf("error result should return the seed")
 	}
@@ -139,7 +139,7 @@ func TestSuccessHasCorrectSeedInResult(t *testing.T) {
 			return true, nil
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	if !strings.Contains(fmt.Sprintf("%v", result), fmt.Sprintf("%v", rng)) {
 		t.Errorf("result should return the seed")
 	}
@@ -154,7 +154,7 @@ func TestExpectSuccess(t *testing.T) {
 			return true, nil
 		},
 	)
-	result := actual.Run(RunParms{200, rng})
+	result := actual.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[int](t, result)
 }
 
@@ -182,7 +182,7 @@ func TestAssertionOr(t *testing.T) {
 		func(xs []int) []int {
 			return xs
 		}, AssertionOr(assertion1, assertion2))
-	result := lengthGEOne.Run(RunParms{200, rng})
+	result := lengthGEOne.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
 
@@ -210,7 +210,7 @@ func TestAssertionAndFailure(t *testing.T) {
 		func(xs []int) []int {
 			return xs
 		}, AssertionAnd(assertion1, assertion2))
-	result := lengthGEOne.Run(RunParms{200, rng})
+	result := lengthGEOne.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectFailure[[]int](t, result)
 }
 
@@ -238,6 +238,6 @@ func TestAssertionAndSuccess(t *testing.T) {
 		func(xs []int) []int {
 			return xs
 		}, AssertionAnd(assertion1, assertion2))
-	result := prop.Run(RunParms{200, rng})
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
 	ExpectSuccess[[]int](t, result)
 }
@@ -0,0 +1,132 @@
+package propcheck
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// The number of repetitions StringMatching adds to the minimum of an unbounded repetition such as *, + or {2,}.
+// It is also limited by the Size.
+const MaxRepeat = 10
+
+/*
+*
+Generates strings that match the regular expression pattern, which uses the syntax of the regexp package. The whole string matches,
+as if the pattern were anchored with ^ and $. Unbounded repetitions such as * and + repeat at most MaxRepeat times more than
+their minimum, and no more than the Size. Wildcards such as . draw from the printable ASCII characters.
+
+Assertions such as \b can make A generated string fail to match, in which case another is drawn, up to MaxFilterTries times after
+which the Gen panics. The Gen shrinks to shorter strings that still match. StringMatching panics if the pattern is not valid.
+
+	semver := propcheck.StringMatching(`(0|[1-9][0-9]{0,2})\.(0|[1-9][0-9]{0,2})\.(0|[1-9][0-9]{0,2})`)
+	email := propcheck.StringMatching(`[a-z][a-z0-9.]{0,15}@[a-z]{1,10}\.(com|org|net)`)
+*/
+func StringMatching(pattern string) Gen[string] {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		panic(fmt.Sprintf("StringMatching could not parse %q: %v", pattern, err))
+	}
+	anchored := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	g := genWithSize(func(rng RNG, size Size) (string, RNG) {
+		for x := 0; x < MaxFilterTries; x++ {
+			var b strings.Builder
+			rng = generateMatch(&b, re, rng, size)
+			if s := b.String(); anchored.MatchString(s) {
+				return s, rng
+			}
+		}
+		panic(fmt.Sprintf("StringMatching could not generate a string matching %q after %v tries", pattern, MaxFilterTries))
+	})
+	return g.WithShrinker(filterShrinker(ShrinkString, anchored.MatchString))
+}
+
+// Writes A random string matching re to b and returns the next RNG.
+func generateMatch(b *strings.Builder, re *syntax.Regexp, rng RNG, size Size) RNG {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 {
+				var i int
+				i, rng = rng.Intn(2)
+				if i == 0 {
+					c = unicode.SimpleFold(c)
+				}
+			}
+			b.WriteRune(c)
+		}
+	case syntax.OpCharClass:
+		var c rune
+		c, rng = chooseFromClass(re.Rune, rng)
+		b.WriteRune(c)
+	case syntax.OpAnyCharNotNL:
+		var i int
+		i, rng = rng.Intn('~' - ' ' + 1)
+		b.WriteRune(rune(' ' + i))
+	case syntax.OpAnyChar:
+		var i int
+		i, rng = rng.Intn('~' - ' ' + 2)
+		if i == '~'-' '+1 {
+			b.WriteRune('\n')
+		} else {
+			b.WriteRune(rune(' ' + i))
+		}
+	case syntax.OpCapture:
+		rng = generateMatch(b, re.Sub[0], rng, size)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			rng = generateMatch(b, sub, rng, size)
+		}
+	case syntax.OpAlternate:
+		var i int
+		i, rng = rng.Intn(len(re.Sub))
+		rng = generateMatch(b, re.Sub[i], rng, size)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := repeatBounds(re, size)
+		var n int
+		n, rng = rng.Intn(hi - lo + 1)
+		for x := 0; x < lo+n; x++ {
+			rng = generateMatch(b, re.Sub[0], rng, size)
+		}
+	case syntax.OpNoMatch:
+		panic("StringMatching cannot generate a string for a pattern that matches nothing")
+	default: //Empty matches and assertions such as ^, $ and \b match without consuming anything.
+	}
+	return rng
+}
+
+// The bounds on the number of repetitions of A repetition operator.
+func repeatBounds(re *syntax.Regexp, size Size) (int, int) {
+	lo, hi := re.Min, re.Max
+	switch re.Op {
+	case syntax.OpStar:
+		lo, hi = 0, -1
+	case syntax.OpPlus:
+		lo, hi = 1, -1
+	case syntax.OpQuest:
+		lo, hi = 0, 1
+	}
+	if hi == -1 {
+		hi = lo + min(MaxRepeat, size)
+	}
+	return lo, hi
+}
+
+// Chooses A rune uniformly from A character class given as pairs of inclusive bounds, as in syntax.Regexp.Rune.
+func chooseFromClass(class []rune, rng RNG) (rune, RNG) {
+	total := 0
+	for x := 0; x < len(class); x += 2 {
+		total += int(class[x+1]-class[x]) + 1
+	}
+	i, r := rng.Intn(total)
+	for x := 0; x < len(class); x += 2 {
+		n := int(class[x+1]-class[x]) + 1
+		if i < n {
+			return class[x] + rune(i), r
+		}
+		i -= n
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,75 @@
+package propcheck
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestStringMatchingGeneratesMatches(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, pattern := range []string{
+		`[A-Z]{3}-\d{4}`,
+		`[a-z][a-z0-9.]{0,15}@[a-z]{1,10}\.(com|org|net)`,
+		`(0|[1-9][0-9]{0,2})\.(0|[1-9][0-9]{0,2})\.(0|[1-9][0-9]{0,2})`,
+		`([a-z0-9]+\.)*[a-z]+`,
+		`(?i)hello, .+!`,
+		`^\w+\b \b\w*$`,
+		`a|b*|c?d`,
+		`\p{Greek}+`,
+	} {
+		re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for _, s := range StringMatching(pattern).Sample(rng, 200) {
+			if !re.MatchString(s) {
+				t.Errorf("%q did not match %v", s, pattern)
+			}
+		}
+	}
+}
+
+func TestStringMatchingBoundsRepetition(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	longest := 0
+	for _, s := range StringMatching(`x*y+`).Sample(rng, 500) {
+		longest = max(longest, len(s))
+	}
+	if longest > 1+2*MaxRepeat {
+		t.Errorf("Repetitions should have been bounded by MaxRepeat but a string had %v characters", longest)
+	}
+	if longest < MaxRepeat {
+		t.Errorf("Some strings should have had about MaxRepeat repetitions but the longest had %v characters", longest)
+	}
+}
+
+func TestStringMatchingShrinksToShorterMatches(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	pattern := `[a-z]+@[a-z]+\.(com|org)`
+	prop := ForAll(StringMatching(pattern), "Emails are short",
+		func(s string) string { return s },
+		func(s string) (bool, error) {
+			if len(s) > 12 {
+				return false, fmt.Errorf("%q was too long", s)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectFailure[string](t, result)
+	f := result.(Falsified[string])
+	if !regexp.MustCompile(`^(?:` + pattern + `)$`).MatchString(f.FailedCase) {
+		t.Errorf("The counterexample %q should have matched %v", f.FailedCase, pattern)
+	}
+	if len(f.FailedCase) != 13 {
+		t.Errorf("The counterexample should have shrunk to 13 characters but was %q", f.FailedCase)
+	}
+}
+
+func TestStringMatchingPanicsForBadPatterns(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("StringMatching should have panicked for an invalid pattern")
+		}
+	}()
+	StringMatching(`[a-`)
+}
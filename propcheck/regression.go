@@ -0,0 +1,112 @@
+package propcheck
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+/*
+*
+The directory, relative to the package under test, where Check records the seeds that falsified A property, one file per test named after
+the test and its subtests. Check replays the recorded seeds before drawing A new one, so A bug found once, for instance in CI, is looked for
+again on every run even after it is fixed. Commit the directory with the code. Each line of A file is A seed followed by the shrunk
+counterexample it produced, for reference; delete A line to stop replaying its seed.
+*/
+const RegressionDir = "testdata/propcheck"
+
+// Characters that are not safe in A file name on every platform.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// The file that records the falsifying seeds of the named test in dir.
+func regressionPath(dir, testName string) string {
+	return filepath.Join(dir, unsafeFileChars.ReplaceAllString(testName, "_"))
+}
+
+// Reads the seeds recorded in the file at path. A missing file has no seeds.
+func loadRegressions(path string) ([]RNG, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var seeds []RNG
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		rng, err := ParseSeed(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%v:%v: %w", path, line, err)
+		}
+		seeds = append(seeds, rng)
+	}
+	return seeds, scanner.Err()
+}
+
+// Appends the seed of rng and the counterexample it produced to the file at path, unless the seed is already there.
+// An RNG whose seed cannot be formatted is an error.
+func saveRegression(path string, rng RNG, counterexample string) error {
+	seed, ok := FormatSeed(rng)
+	if !ok {
+		return fmt.Errorf("the seed of %v cannot be formatted", rng)
+	}
+	seeds, err := loadRegressions(path)
+	if err != nil {
+		return err
+	}
+	for _, s := range seeds {
+		if formatted, _ := FormatSeed(s); formatted == seed {
+			return nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if len(seeds) == 0 {
+		fmt.Fprintf(f, "# Seeds that falsified %v, replayed by propcheck.Check before new ones. Each is followed by its counterexample.\n", filepath.Base(path))
+	}
+	_, err = fmt.Fprintf(f, "%v %v\n", seed, strings.Join(strings.Fields(counterexample), " "))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Replays the seeds recorded in the file at path, returning the Result and report of the first one that falsifies the property again.
+func replayRegressions(testName string, p Prop, parms RunParms, path string) (Result, string, bool) {
+	seeds, err := loadRegressions(path)
+	if err != nil {
+		return nil, fmt.Sprintf("Could not read the recorded seeds of %q: %v", p.Name, err), false
+	}
+	for _, seed := range seeds {
+		parms.Rng = seed
+		if result, report, ok := runCheck(testName, p, parms); !ok {
+			return result, fmt.Sprintf("A seed recorded in %v falsified the property again.\n%v", path, report), false
+		}
+	}
+	return nil, "", true
+}
+
+// Implemented by Falsified so that Check can record its counterexample without knowing its type.
+type counterexample interface {
+	counterexample() string
+}
+
+func (w Falsified[A]) counterexample() string {
+	return fmt.Sprintf("%v", w.FailedCase)
+}
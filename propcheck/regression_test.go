@@ -0,0 +1,106 @@
+package propcheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fails for the one seed it is told to, so that replaying can be tested without depending on what A Gen produces.
+func failsForSeed(bad RNG) Prop {
+	return Prop{
+		Name: "Fails for one seed",
+		Run: func(p RunParms) Result {
+			if p.Rng == bad {
+				return Falsified[int]{Name: "Fails for one seed", FailedCase: 7, Seed: p.Rng}
+			}
+			return Passed[int]{}
+		},
+	}
+}
+
+func TestRegressionPath(t *testing.T) {
+	if p := regressionPath("testdata/propcheck", "TestX/Numbers <= 100"); p != filepath.Join("testdata", "propcheck", "TestX_Numbers____100") {
+		t.Errorf("Unsafe characters should have been replaced but the path was %v", p)
+	}
+}
+
+func TestSaveAndLoadRegressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "propcheck", "TestX")
+	if seeds, err := loadRegressions(path); err != nil || seeds != nil {
+		t.Fatalf("A missing file should have had no seeds but had %v, %v", seeds, err)
+	}
+	for _, rng := range []RNG{SimpleRNG{Seed: 42}, SplitMix64{State: 7}, SimpleRNG{Seed: 42}} {
+		if err := saveRegression(path, rng, "[1 2\n 3]"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := saveRegression(path, BytesRNG{}, "[]"); err == nil {
+		t.Errorf("An RNG whose seed cannot be formatted should not have been recorded")
+	}
+	seeds, err := loadRegressions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(seeds) != fmt.Sprint([]RNG{SimpleRNG{Seed: 42}, SplitMix64{State: 7}}) {
+		t.Errorf("Each seed should have been recorded once but the seeds were %v", seeds)
+	}
+	contents, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(contents), "# ") || !strings.Contains(string(contents), "\n42 [1 2 3]\n") {
+		t.Errorf("The file should have had a comment and one line per seed but was:\n%s", contents)
+	}
+}
+
+func TestLoadRegressionsRejectsMalformedSeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestX")
+	os.WriteFile(path, []byte("# A comment\n\nnot-a-seed []\n"), 0644)
+	if _, err := loadRegressions(path); err == nil || !strings.Contains(err.Error(), ":3:") {
+		t.Errorf("The malformed seed on line 3 should have been an error but was %v", err)
+	}
+}
+
+func TestReplayRegressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestX")
+	bad := SimpleRNG{Seed: 13634551}
+	prop := failsForSeed(bad)
+	if _, report, ok := replayRegressions("TestX", prop, RunParms{TestCases: 1}, path); !ok {
+		t.Errorf("There were no seeds to replay but the replay failed with %v", report)
+	}
+	saveRegression(path, SimpleRNG{Seed: 1}, "")
+	saveRegression(path, bad, "7")
+	_, report, ok := replayRegressions("TestX", prop, RunParms{TestCases: 1}, path)
+	if ok {
+		t.Fatalf("The recorded seed should have falsified the property again")
+	}
+	for _, expected := range []string{path, "Counterexample:  7\n", "PROPCHECK_SEED=13634551"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report should have contained %q but was:\n%v", expected, report)
+		}
+	}
+}
+
+func TestCheckReplaysRecordedSeeds(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "")
+	bad := SimpleRNG{Seed: 13634551}
+	var replayed []RNG
+	prop := Prop{
+		Run: func(p RunParms) Result {
+			replayed = append(replayed, p.Rng)
+			return Passed[int]{}
+		},
+	}
+	dir := t.TempDir()
+	saveRegression(regressionPath(dir, t.Name()), bad, "7")
+	Check(t, prop, WithSeed(42), WithRegressionDir(dir))
+	if fmt.Sprint(replayed) != fmt.Sprint([]RNG{bad, SimpleRNG{Seed: 42}}) {
+		t.Errorf("The recorded seed should have run before the new one but the seeds were %v", replayed)
+	}
+	replayed = nil
+	Check(t, prop, WithSeed(42), WithRegressionDir(dir), WithoutRegressions())
+	if fmt.Sprint(replayed) != fmt.Sprint([]RNG{SimpleRNG{Seed: 42}}) {
+		t.Errorf("Only the new seed should have run but the seeds were %v", replayed)
+	}
+}
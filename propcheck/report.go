@@ -0,0 +1,347 @@
+package propcheck
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// The environment variable Check reads A report destination from, e.g. "junit:propcheck.xml" or "json:propcheck.json".
+const ReportEnv = "PROPCHECK_REPORT"
+
+// The environment variable that turns off the ANSI colors of Falsified.String, ExpectSuccess and ExpectFailure. See https://no-color.org.
+const NoColorEnv = "NO_COLOR"
+
+// The name of the test flag Check reads A report destination from, which takes precedence over ReportEnv. Like SeedFlag, propcheck does
+// not register it; A package whose tests want it registers it with flag.String.
+const ReportFlag = "propcheck.report"
+
+// The Status of A PropertyReport.
+const (
+	StatusPassed    = "passed"
+	StatusFalsified = "falsified"
+	StatusGaveUp    = "gave up"
+	StatusError     = "error" //The property did not produce A Result.
+)
+
+// Formats A counterexample for A PropertyReport. See WithPrinter.
+type Printer = func(any) string
+
+// The default Printer, which formats A value with Go syntax, e.g. []int{1, 2}.
+func GoSyntax(a any) string {
+	return fmt.Sprintf("%#v", a)
+}
+
+/*
+*
+A machine-readable account of one checked property. Check collects one for every property it runs and writes them all, as JSON or as
+JUnit XML, to the destination given with the -propcheck.report flag or the PROPCHECK_REPORT environment variable, e.g.
+
+	PROPCHECK_REPORT=junit:propcheck.xml go test ./...
+
+A relative path is relative to the directory of each package, which is where go test runs its tests. The file is rewritten after every
+property so that it is complete even if A later test panics.
+*/
+type PropertyReport struct {
+	Test           string         `json:"test"` //The name of the Go test, including subtests.
+	Name           string         `json:"name"` //The name of the property.
+	Status         string         `json:"status"`
+	Seed           string         `json:"seed,omitempty"`
+	TestCases      int            `json:"testCases"` //The number of test cases that passed.
+	Discards       int            `json:"discards"`
+	Shrinks        int            `json:"shrinks"`
+	Counterexample string         `json:"counterexample,omitempty"`
+	Errors         []string       `json:"errors,omitempty"` //Each error of A multierror separately.
+	Panic          string         `json:"panic,omitempty"`
+	Labels         map[string]int `json:"labels,omitempty"`
+	Replay         string         `json:"replay,omitempty"` //A command that replays A failure.
+	Duration       time.Duration  `json:"-"`
+}
+
+// Adds the Duration as A number of seconds.
+func (w PropertyReport) MarshalJSON() ([]byte, error) {
+	type plain PropertyReport
+	return json.Marshal(struct {
+		plain
+		Seconds float64 `json:"seconds"`
+	}{plain(w), w.Duration.Seconds()})
+}
+
+// Implemented by the Results of ForAll so that NewPropertyReport needs no type parameter.
+type reportable interface {
+	propertyReport(printer Printer) PropertyReport
+}
+
+func (w Passed[A]) propertyReport(Printer) PropertyReport {
+	return PropertyReport{Status: StatusPassed, Seed: formatSeed(w.Seed), TestCases: w.TestCases, Discards: w.Discards, Labels: w.Labels}
+}
+
+func (w Falsified[A]) propertyReport(printer Printer) PropertyReport {
+	r := PropertyReport{Status: StatusFalsified, Seed: formatSeed(w.Seed), TestCases: w.Successes, Discards: w.Discards, Shrinks: w.Shrinks,
+		Counterexample: printer(w.FailedCase), Errors: errorStrings(w.Errors)}
+	if w.Panic != nil {
+		r.Panic = fmt.Sprintf("%v", w.Panic)
+	}
+	return r
+}
+
+func (w GaveUp[A]) propertyReport(Printer) PropertyReport {
+	return PropertyReport{Status: StatusGaveUp, Seed: formatSeed(w.Seed), TestCases: w.Successes, Discards: w.Discards}
+}
+
+// Like FormatSeed but formats an RNG it does not know with %v, and nil as "".
+func formatSeed(rng RNG) string {
+	if rng == nil {
+		return ""
+	}
+	s, _ := FormatSeed(rng)
+	return s
+}
+
+// The messages of each error of A multierror, or of err on its own.
+func errorStrings(err error) []string {
+	var m *multierror.Error
+	if errors.As(err, &m) {
+		var r []string
+		for _, e := range m.Errors {
+			r = append(r, e.Error())
+		}
+		return r
+	}
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+/*
+*
+Makes A PropertyReport of the Result of the property named propName, run by the Go test named testName, which took elapsed. The counterexample
+of A falsified property is formatted with printer, or with GoSyntax when printer is nil. A Result that is not one of Passed, Falsified or
+GaveUp is reported with just its status.
+*/
+func NewPropertyReport(testName, propName string, result Result, elapsed time.Duration, printer Printer) PropertyReport {
+	if printer == nil {
+		printer = GoSyntax
+	}
+	var r PropertyReport
+	switch v := result.(type) {
+	case nil:
+		r = PropertyReport{Status: StatusError, Errors: []string{"the property did not produce a result"}}
+	case reportable:
+		r = v.propertyReport(printer)
+	default:
+		r = PropertyReport{Status: StatusPassed}
+		if v.IsFalsified() {
+			r = PropertyReport{Status: StatusFalsified, Counterexample: fmt.Sprintf("%v", v)}
+		}
+	}
+	if rng, err := ParseSeed(r.Seed); r.Status == StatusFalsified && err == nil {
+		r.Replay = replayCommandFor(testName, rng)
+	}
+	r.Test, r.Name, r.Duration = testName, propName, elapsed
+	return r
+}
+
+// Writes the reports as an indented JSON array.
+func WriteJSON(w io.Writer, reports []PropertyReport) error {
+	if reports == nil {
+		reports = []PropertyReport{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitProblem   `xml:"failure,omitempty"`
+	Error      *junitProblem   `xml:"error,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+/*
+*
+Writes the reports as JUnit XML. There is A testsuite for each top-level Go test and A testcase for each property, whose classname is
+the top-level test. A falsified property is A failure and A property that gave up or did not produce A Result is an error. The seed,
+test cases and discards of each property are testcase properties.
+*/
+func WriteJUnit(w io.Writer, reports []PropertyReport) error {
+	suites := junitSuites{}
+	index := map[string]int{}
+	var total time.Duration
+	var durations []time.Duration
+	for _, r := range reports {
+		top, _, _ := strings.Cut(r.Test, "/")
+		i, ok := index[top]
+		if !ok {
+			i = len(suites.Suites)
+			index[top] = i
+			suites.Suites = append(suites.Suites, junitSuite{Name: top})
+			durations = append(durations, 0)
+		}
+		s := &suites.Suites[i]
+		name := r.Name
+		if name == "" {
+			name = r.Test
+		}
+		c := junitCase{Name: name, Classname: top, Time: junitTime(r.Duration), Properties: []junitProperty{
+			{"seed", r.Seed}, {"testCases", fmt.Sprint(r.TestCases)}, {"discards", fmt.Sprint(r.Discards)},
+		}}
+		switch r.Status {
+		case StatusFalsified:
+			c.Failure = &junitProblem{Message: fmt.Sprintf("falsified by %v", r.Counterexample), Type: r.Status, Body: junitBody(r)}
+			s.Failures++
+			suites.Failures++
+		case StatusGaveUp, StatusError:
+			c.Error = &junitProblem{Message: r.Status, Type: r.Status, Body: junitBody(r)}
+			s.Errors++
+			suites.Errors++
+		}
+		s.Cases = append(s.Cases, c)
+		s.Tests++
+		suites.Tests++
+		durations[i] += r.Duration
+		total += r.Duration
+	}
+	for i, d := range durations {
+		suites.Suites[i].Time = junitTime(d)
+	}
+	suites.Time = junitTime(total)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// The plain text body of A JUnit failure or error.
+func junitBody(r PropertyReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Seed:            %v\n", r.Seed)
+	if r.Counterexample != "" {
+		fmt.Fprintf(&b, "Counterexample:  %v\n", r.Counterexample)
+	}
+	for _, e := range r.Errors {
+		fmt.Fprintf(&b, "Error:           %v\n", e)
+	}
+	if r.Panic != "" {
+		fmt.Fprintf(&b, "Panic:           %v\n", r.Panic)
+	}
+	if r.Replay != "" {
+		fmt.Fprintf(&b, "Replay:          %v\n", r.Replay)
+	}
+	return b.String()
+}
+
+// The reports Check has collected in this test binary, which are rewritten to the report destination after every property.
+var collected struct {
+	sync.Mutex
+	reports []PropertyReport
+}
+
+// The format and path of the report destination from -propcheck.report or ReportEnv, or false if there is none.
+func reportDestination() (format, path string, ok bool, err error) {
+	s := os.Getenv(ReportEnv)
+	if f := lookupFlag(ReportFlag); f != "" {
+		s = f
+	}
+	if s == "" {
+		return "", "", false, nil
+	}
+	format, path, found := strings.Cut(s, ":")
+	if !found || path == "" || (format != "json" && format != "junit") {
+		return "", "", false, fmt.Errorf("the propcheck report destination %q should be json:<path> or junit:<path>", s)
+	}
+	return format, path, true, nil
+}
+
+// Adds r to the collected reports and rewrites the report destination, if there is one.
+func collectReport(r PropertyReport) error {
+	format, path, ok, err := reportDestination()
+	if !ok {
+		return err
+	}
+	collected.Lock()
+	defer collected.Unlock()
+	collected.reports = append(collected.reports, r)
+	reports := collected.reports
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		err = WriteJSON(f, reports)
+	} else {
+		err = WriteJUnit(f, reports)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Wraps s in the ANSI escape code for color and A reset, unless NoColorEnv is set to anything.
+func colorize(color, s string) string {
+	if os.Getenv(NoColorEnv) != "" {
+		return s
+	}
+	return color + s + "\u001B[0m"
+}
+
+// The ANSI escape code for red text.
+const red = "\u001B[31m"
@@ -0,0 +1,160 @@
+package propcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var _ = flag.String(ReportFlag, "", "write a report of every checked property to json:<path> or junit:<path>")
+
+// Sets the -propcheck.report flag for the duration of the test and forgets the reports collected meanwhile.
+func setReportFlag(t *testing.T, destination string) {
+	old := lookupFlag(ReportFlag)
+	flag.Set(ReportFlag, destination)
+	t.Cleanup(func() {
+		flag.Set(ReportFlag, old)
+		collected.Lock()
+		collected.reports = nil
+		collected.Unlock()
+	})
+}
+
+var tooLarge = ForAll(ChooseArray(1, 10, ChooseInt(0, 1000)), "Elements are less than 100",
+	func(xs []int) []int { return xs },
+	func(xs []int) (bool, error) {
+		var errs error
+		for _, x := range xs {
+			if x >= 100 {
+				errs = multierror.Append(errs, fmt.Errorf("%v was too large", x))
+			}
+		}
+		return errs == nil, errs
+	},
+)
+
+func TestNewPropertyReportOfFalsifiedProperty(t *testing.T) {
+	result := tooLarge.Run(RunParms{TestCases: 100, Rng: SimpleRNG{Seed: 13634551}})
+	r := NewPropertyReport("TestX/Elements_are_less_than_100", tooLarge.Name, result, time.Second, nil)
+	f := result.(Falsified[[]int])
+	if r.Status != StatusFalsified || r.Seed != "13634551" || r.TestCases != f.Successes || r.Shrinks != f.Shrinks || r.Duration != time.Second {
+		t.Errorf("The report should have described %v but was %+v", f, r)
+	}
+	if r.Counterexample != fmt.Sprintf("%#v", f.FailedCase) || len(r.Errors) == 0 || !strings.HasSuffix(r.Errors[0], "was too large") {
+		t.Errorf("The counterexample should have been in Go syntax with each error separate but were %v and %q", r.Counterexample, r.Errors)
+	}
+	if !strings.Contains(r.Replay, "PROPCHECK_SEED=13634551") || !strings.Contains(r.Replay, "^Elements_are_less_than_100$") {
+		t.Errorf("The replay command should have re-run the property but was %v", r.Replay)
+	}
+	if r := NewPropertyReport("TestX", tooLarge.Name, result, 0, func(a any) string { return "custom" }); r.Counterexample != "custom" {
+		t.Errorf("The counterexample should have been formatted by the printer but was %v", r.Counterexample)
+	}
+}
+
+func TestNewPropertyReportOfOtherResults(t *testing.T) {
+	for _, c := range []struct {
+		result Result
+		status string
+	}{
+		{Passed[int]{Seed: SimpleRNG{Seed: 1}, TestCases: 100}, StatusPassed},
+		{GaveUp[int]{Seed: SimpleRNG{Seed: 1}, Discards: 1000}, StatusGaveUp},
+		{nil, StatusError},
+	} {
+		if r := NewPropertyReport("TestX", "p", c.result, 0, nil); r.Status != c.status || r.Replay != "" {
+			t.Errorf("%v should have been reported as %v without a replay command but was %+v", c.result, c.status, r)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	reports := []PropertyReport{{Test: "TestX/p", Name: "p", Status: StatusFalsified, Seed: "7", Counterexample: "[]int{100}",
+		Errors: []string{"100 was too large"}, Duration: 1500 * time.Millisecond}}
+	if err := WriteJSON(&b, reports); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("The JSON %s should have been valid: %v", b.Bytes(), err)
+	}
+	if len(decoded) != 1 || decoded[0]["counterexample"] != "[]int{100}" || decoded[0]["seconds"] != 1.5 || decoded[0]["status"] != "falsified" {
+		t.Errorf("The JSON should have described the report but was %s", b.Bytes())
+	}
+	b.Reset()
+	WriteJSON(&b, nil)
+	if strings.TrimSpace(b.String()) != "[]" {
+		t.Errorf("No reports should have been an empty array but were %v", b.String())
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	reports := []PropertyReport{
+		{Test: "TestX/p", Name: "p", Status: StatusPassed, Seed: "7", TestCases: 100, Duration: time.Second},
+		{Test: "TestX/q", Name: "q", Status: StatusFalsified, Seed: "7", Counterexample: "[]int{100}", Errors: []string{"100 was too large"}},
+		{Test: "TestY", Status: StatusGaveUp, Seed: "7", Discards: 1000},
+	}
+	if err := WriteJUnit(&b, reports); err != nil {
+		t.Fatal(err)
+	}
+	var decoded junitSuites
+	if err := xml.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("The XML %s should have been valid: %v", b.Bytes(), err)
+	}
+	if decoded.Tests != 3 || decoded.Failures != 1 || decoded.Errors != 1 || len(decoded.Suites) != 2 {
+		t.Fatalf("There should have been 3 tests in 2 suites with a failure and an error but the XML was:\n%s", b.Bytes())
+	}
+	x := decoded.Suites[0]
+	if x.Name != "TestX" || x.Time != "1.000" || len(x.Cases) != 2 || x.Cases[1].Failure == nil || x.Cases[1].Classname != "TestX" {
+		t.Errorf("TestX should have had a passing and a failing testcase but was %+v", x)
+	}
+	if !strings.Contains(x.Cases[1].Failure.Body, "100 was too large") || x.Cases[0].Properties[0] != (junitProperty{"seed", "7"}) {
+		t.Errorf("The failure should have had the errors and the seed should have been a property but the XML was:\n%s", b.Bytes())
+	}
+	if y := decoded.Suites[1]; y.Cases[0].Name != "TestY" || y.Cases[0].Error == nil {
+		t.Errorf("TestY should have been an error named after the test but was %+v", y)
+	}
+}
+
+func TestCheckWritesReport(t *testing.T) {
+	for _, format := range []string{"json", "junit"} {
+		path := filepath.Join(t.TempDir(), "reports", "propcheck."+format)
+		setReportFlag(t, format+":"+path)
+		Check(t, ForAll(Int(), "Anything goes", func(x int) int { return x }, func(int) (bool, error) { return true, nil }), WithTestCases(5))
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(contents), "Anything goes") {
+			t.Errorf("The %v report should have described the property but was:\n%s", format, contents)
+		}
+	}
+}
+
+func TestReportDestinationRejectsUnknownFormat(t *testing.T) {
+	setReportFlag(t, "yaml:propcheck.yaml")
+	if _, _, ok, err := reportDestination(); ok || err == nil {
+		t.Errorf("An unknown format should have been an error")
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	f := Falsified[int]{Name: "p", FailedCase: 1, Seed: SimpleRNG{Seed: 1}}
+	t.Setenv(NoColorEnv, "")
+	if s := f.String(); !strings.HasPrefix(s, red) || !strings.HasSuffix(s, "\u001B[0m") {
+		t.Errorf("The report should have been red and reset the color but was %q", s)
+	}
+	t.Setenv(NoColorEnv, "1")
+	if s := f.String(); strings.Contains(s, "\u001B") {
+		t.Errorf("The report should not have had colors with %v set but was %q", NoColorEnv, s)
+	}
+}
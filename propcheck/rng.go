@@ -0,0 +1,145 @@
+package propcheck
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// An RNG is A pure random number generator. No method mutates its receiver; each returns the next RNG instead, so the same RNG
+// always produces the same values and can be freely shared between Go Routines. Generators thread an RNG through every call.
+//
+// SimpleRNG is the default and replays every seed exactly as earlier versions of propcheck did. SplitMix64 has better statistical
+// quality, full 64-bit output and unbiased bounded integers.
+type RNG interface {
+	// Returns A random int and the next RNG.
+	NextInt() (int, RNG)
+	// Returns 64 random bits and the next RNG.
+	Uint64() (uint64, RNG)
+	// Returns A random int in [0, n) and the next RNG. n must be greater than zero.
+	Intn(n int) (int, RNG)
+	// Returns two RNGs that produce independent streams of values.
+	Split() (RNG, RNG)
+}
+
+// A 48-bit linear congruential generator, the same one used by java.util.Random. Only 32 bits of each value are random and
+// low bits are strongly correlated, but its seeds replay the values of earlier versions of propcheck.
+type SimpleRNG struct {
+	Seed int
+}
+
+func (w SimpleRNG) String() string {
+	return fmt.Sprintf("SimpleRMG{Seed: %v}", w.Seed)
+}
+
+func NextInt(r SimpleRNG) (int, SimpleRNG) {
+	newSeed := (r.Seed*0x5DEECE66D + 0xB) & 0xFFFFFFFFFFFF
+	nextRNG := SimpleRNG{newSeed}
+	n := newSeed >> 16
+	return n, nextRNG
+}
+
+func (w SimpleRNG) NextInt() (int, RNG) {
+	return NextInt(w)
+}
+
+func (w SimpleRNG) Uint64() (uint64, RNG) {
+	hi, r1 := NextInt(w)
+	lo, r2 := NextInt(r1)
+	return uint64(hi)<<32 | uint64(lo)&0xFFFFFFFF, r2
+}
+
+// Takes the next int modulo n, which is slightly biased but matches earlier versions of propcheck.
+func (w SimpleRNG) Intn(n int) (int, RNG) {
+	i, r := NextInt(w)
+	return i % n, r
+}
+
+func (w SimpleRNG) Split() (RNG, RNG) {
+	i, r := NextInt(w)
+	return r, SimpleRNG{Seed: int(mix64(uint64(w.Seed) ^ uint64(i)))}
+}
+
+const splitMixGamma = 0x9E3779B97F4A7C15
+
+// The SplitMix64 generator of Steele, Lea and Flood, "Fast Splittable Pseudorandom Number Generators".
+type SplitMix64 struct {
+	State uint64
+}
+
+func (w SplitMix64) String() string {
+	return fmt.Sprintf("SplitMix64{State: %v}", w.State)
+}
+
+func (w SplitMix64) Uint64() (uint64, RNG) {
+	s := w.State + splitMixGamma
+	return mix64(s), SplitMix64{State: s}
+}
+
+func (w SplitMix64) NextInt() (int, RNG) {
+	u, r := w.Uint64()
+	return int(u), r
+}
+
+// Uses Lemire's multiply-and-reject method so that every value in [0, n) is equally likely.
+func (w SplitMix64) Intn(n int) (int, RNG) {
+	if n <= 0 {
+		panic(fmt.Sprintf("Intn requires n > 0 but n was %v", n))
+	}
+	var r RNG = w
+	bound := uint64(n)
+	threshold := -bound % bound
+	for {
+		var u uint64
+		u, r = r.Uint64()
+		hi, lo := bits.Mul64(u, bound)
+		if lo >= threshold {
+			return int(hi), r
+		}
+	}
+}
+
+func (w SplitMix64) Split() (RNG, RNG) {
+	a, r1 := w.Uint64()
+	b, _ := r1.Uint64()
+	return SplitMix64{State: a}, SplitMix64{State: b}
+}
+
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// The prefix that marks A SplitMix64 seed, e.g. "splitmix64:1234". A seed without A prefix is A SimpleRNG seed.
+const splitMixSeedPrefix = "splitmix64:"
+
+// Formats the seed of an RNG so that ParseSeed gives back the same RNG. The bool is false for RNG implementations
+// that propcheck does not know how to format.
+func FormatSeed(rng RNG) (string, bool) {
+	switch r := rng.(type) {
+	case SimpleRNG:
+		return strconv.Itoa(r.Seed), true
+	case SplitMix64:
+		return splitMixSeedPrefix + strconv.FormatUint(r.State, 10), true
+	default:
+		return fmt.Sprintf("%v", rng), false
+	}
+}
+
+// Parses A seed written by FormatSeed: an integer for A SimpleRNG or "splitmix64:<integer>" for A SplitMix64.
+func ParseSeed(s string) (RNG, error) {
+	if strings.HasPrefix(s, splitMixSeedPrefix) {
+		n, err := strconv.ParseUint(strings.TrimPrefix(s, splitMixSeedPrefix), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return SplitMix64{State: n}, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
+	}
+	return SimpleRNG{Seed: n}, nil
+}
@@ -0,0 +1,125 @@
+package propcheck
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSplitMix64KnownValues(t *testing.T) {
+	var rng RNG = SplitMix64{State: 0}
+	expected := []uint64{0xE220A8397B1DCDAF, 0x6E789E6AA1B965F4, 0x06C45D188009454F}
+	for _, e := range expected {
+		var u uint64
+		u, rng = rng.Uint64()
+		if u != e {
+			t.Errorf("Expected %x but was %x", e, u)
+		}
+	}
+}
+
+func TestSplitMix64IntnIsInRange(t *testing.T) {
+	rng := SplitMix64{State: uint64(time.Now().UnixNano())}
+	prop := ForAll(Product(ChooseInt(1, 1000000), ChooseInt(-1000, 1000)), "Choosing from a range must stay in the range.",
+		func(p Pair[int, int]) Pair[int, int] {
+			x, _ := ChooseInt(p.B, p.B+p.A).Run(SplitMix64{State: uint64(p.A)})
+			return Pair[int, int]{p.A, x - p.B}
+		},
+		func(p Pair[int, int]) (bool, error) {
+			if p.B < 0 || p.B >= p.A {
+				return false, fmt.Errorf("%v was not in [0, %v)", p.B, p.A)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 500, Rng: rng})
+	ExpectSuccess[Pair[int, int]](t, result)
+}
+
+func TestSplitMix64IntnIsUniform(t *testing.T) {
+	var rng RNG = SplitMix64{State: uint64(time.Now().UnixNano())}
+	counts := make([]int, 3)
+	n := 30000
+	for x := 0; x < n; x++ {
+		var i int
+		i, rng = rng.Intn(3)
+		counts[i]++
+	}
+	for i, c := range counts {
+		if c < n/3-n/30 || c > n/3+n/30 {
+			t.Errorf("Value %v was chosen %v times out of %v which is not close to uniform: %v", i, c, n, counts)
+		}
+	}
+}
+
+func TestSplitMix64IntProducesNegativeNumbers(t *testing.T) {
+	rng := SplitMix64{State: uint64(time.Now().UnixNano())}
+	negative := false
+	for _, x := range Int().Sample(rng, 100) {
+		if x < 0 {
+			negative = true
+		}
+	}
+	if !negative {
+		t.Errorf("Int should use all 64 bits and produce negative numbers with SplitMix64")
+	}
+}
+
+func TestSplitProducesIndependentStreams(t *testing.T) {
+	for _, rng := range []RNG{SimpleRNG{Seed: time.Now().Nanosecond()}, SplitMix64{State: uint64(time.Now().UnixNano())}} {
+		l, r := rng.Split()
+		ls := Int().Sample(l, 10)
+		rs := Int().Sample(r, 10)
+		same := 0
+		for i := range ls {
+			if ls[i] == rs[i] {
+				same++
+			}
+		}
+		if same > 1 {
+			t.Errorf("Split streams of %v should have been different but were %v and %v", rng, ls, rs)
+		}
+	}
+}
+
+func TestSplitIsPure(t *testing.T) {
+	rng := SplitMix64{State: uint64(time.Now().UnixNano())}
+	l1, r1 := rng.Split()
+	l2, r2 := rng.Split()
+	if l1 != l2 || r1 != r2 {
+		t.Errorf("Splitting the same RNG twice should have produced the same RNGs")
+	}
+}
+
+func TestSimpleRNGIntnMatchesChooseIntOfEarlierVersions(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	n, _ := NextInt(rng)
+	i, _ := rng.Intn(17)
+	if i != n%17 {
+		t.Errorf("SimpleRNG.Intn should have been the next int modulo n, %v, but was %v", n%17, i)
+	}
+}
+
+func TestFormatAndParseSeed(t *testing.T) {
+	for _, rng := range []RNG{SimpleRNG{Seed: 13634551}, SplitMix64{State: 18446744073709551615}} {
+		s, ok := FormatSeed(rng)
+		if !ok {
+			t.Errorf("%v should have been formatted", rng)
+		}
+		r, err := ParseSeed(s)
+		if err != nil || r != rng {
+			t.Errorf("Parsing %v should have produced %v but produced %v, %v", s, rng, r, err)
+		}
+	}
+	if _, err := ParseSeed("splitmix64:x"); err == nil {
+		t.Errorf("An invalid SplitMix64 seed should not have parsed")
+	}
+}
+
+func TestNewRNGReadsSplitMix64Seed(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "splitmix64:5")
+	if rng := NewRNG(); rng != (SplitMix64{State: 5}) {
+		t.Errorf("Expected SplitMix64{State: 5} but was %v", rng)
+	}
+}
@@ -0,0 +1,85 @@
+package propcheck
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// The environment variable NewRNG reads A seed from.
+const SeedEnv = "PROPCHECK_SEED"
+
+// The number of test cases in DefaultRunParms.
+const DefaultTestCases TestCases = 100
+
+/*
+*
+The name of the test flag NewRNG reads A seed from, which takes precedence over SeedEnv. propcheck does not register it, because A library
+must not add flags to every binary that imports it, so A package whose tests want "go test -propcheck.seed=1234" registers it itself:
+
+	var _ = flag.String(propcheck.SeedFlag, "", "seed for the propcheck random number generator")
+*/
+const SeedFlag = "propcheck.seed"
+
+// The value of the named flag of the default flag set, or "" if nothing registered it.
+func lookupFlag(name string) string {
+	if f := flag.Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+/*
+*
+NewRNG makes an RNG for running properties. The seed comes from, in order of precedence:
+
+  - the -propcheck.seed test flag, if the tests registered it, see SeedFlag
+  - the PROPCHECK_SEED environment variable
+  - the current time
+
+A supplied seed is parsed with ParseSeed, so it is either an integer for a SimpleRNG or "splitmix64:<integer>" for a SplitMix64.
+Without a supplied seed NewRNG makes a SimpleRNG. The chosen seed is logged so that a failing run can always be replayed.
+NewRNG panics if a seed that was supplied cannot be parsed.
+*/
+func NewRNG() RNG {
+	source := "time"
+	var rng RNG = SimpleRNG{Seed: time.Now().Nanosecond()}
+	if s, ok := suppliedSeed(); ok {
+		r, err := ParseSeed(s.value)
+		if err != nil {
+			panic(fmt.Sprintf("The propcheck seed %q from %v was not valid: %v", s.value, s.source, err))
+		}
+		source, rng = s.source, r
+	}
+	seed, _ := FormatSeed(rng)
+	log.Printf("propcheck: using seed %v from %v", seed, source)
+	return rng
+}
+
+// DefaultRunParms runs DefaultTestCases test cases with the RNG from NewRNG.
+func DefaultRunParms() RunParms {
+	return RunParms{TestCases: DefaultTestCases, Rng: NewRNG()}
+}
+
+// A copy-pasteable shell command that re-runs the tests in the current package with the given seed.
+func ReplayCommand(rng RNG) string {
+	seed, _ := FormatSeed(rng)
+	return fmt.Sprintf("%v=%v go test -count=1 .", SeedEnv, seed)
+}
+
+type seedSource struct {
+	value  string
+	source string
+}
+
+func suppliedSeed() (seedSource, bool) {
+	if s := lookupFlag(SeedFlag); s != "" {
+		return seedSource{s, "flag -" + SeedFlag}, true
+	}
+	if s, ok := os.LookupEnv(SeedEnv); ok && s != "" {
+		return seedSource{s, "environment variable " + SeedEnv}, true
+	}
+	return seedSource{}, false
+}
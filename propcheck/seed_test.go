@@ -0,0 +1,77 @@
+package propcheck
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Registered the way A package whose tests want the flag registers it.
+var _ = flag.String(SeedFlag, "", "seed for the propcheck random number generator")
+
+// Overrides the -propcheck.seed flag for the duration of the test.
+func setSeedFlag(t *testing.T, seed string) {
+	old := lookupFlag(SeedFlag)
+	flag.Set(SeedFlag, seed)
+	t.Cleanup(func() { flag.Set(SeedFlag, old) })
+}
+
+func TestNewRNGReadsSeedFromEnvironment(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "13634551")
+	rng := NewRNG()
+	if rng != (SimpleRNG{Seed: 13634551}) {
+		t.Errorf("Seed should have been 13634551 but was %v", rng)
+	}
+}
+
+func TestNewRNGPrefersFlagToEnvironment(t *testing.T) {
+	t.Setenv(SeedEnv, "13634551")
+	setSeedFlag(t, "42")
+	rng := NewRNG()
+	if rng != (SimpleRNG{Seed: 42}) {
+		t.Errorf("Seed should have been 42 but was %v", rng)
+	}
+}
+
+func TestNewRNGPanicsOnABadSeed(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "not a number")
+	defer func() {
+		if err := recover(); err == nil {
+			t.Errorf("NewRNG should have panicked")
+		}
+	}()
+	NewRNG()
+}
+
+func TestDefaultRunParms(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "99")
+	p := DefaultRunParms()
+	if p.TestCases != DefaultTestCases || p.Rng != (SimpleRNG{Seed: 99}) {
+		t.Errorf("Expected %v test cases and a seed of 99 but was %v", DefaultTestCases, p)
+	}
+}
+
+func TestFalsifiedIncludesReplayCommand(t *testing.T) {
+	setSeedFlag(t, "")
+	t.Setenv(SeedEnv, "13634551")
+	prop := ForAll(ChooseInt(0, 100), "Always fails.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			return false, fmt.Errorf("failed on %v", x)
+		},
+	)
+	result := prop.Run(DefaultRunParms())
+	if !strings.Contains(fmt.Sprintf("%v", result), "PROPCHECK_SEED=13634551 go test") {
+		t.Errorf("Falsified should have included a replay command but was %v", result)
+	}
+}
+
+func TestUnregisteredFlagIsEmpty(t *testing.T) {
+	if s := lookupFlag("propcheck.unregistered"); s != "" {
+		t.Errorf("A flag nobody registered should have been empty but was %q", s)
+	}
+}
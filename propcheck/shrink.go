@@ -0,0 +1,129 @@
+package propcheck
+
+import "log"
+
+// A Shrinker takes a value that falsified a property and returns a list of "smaller" candidate values, best candidates first.
+// An empty list means the value cannot be shrunk any further.
+type Shrinker[A any] func(A) []A
+
+// The maximum number of successful shrink steps taken for a single counterexample.
+const MaxShrinks = 1000
+
+// A Shrinker that never produces candidates.
+func NoShrink[A any](a A) []A {
+	return nil
+}
+
+// Shrinks an integer toward zero by successively halving the distance to zero.
+// A negative number is first shrunk to its absolute value.
+func ShrinkInt(x int) []int {
+	var r []int
+	if x < 0 && -x > 0 {
+		r = append(r, -x)
+	}
+	for i := x; i != 0; i = i / 2 {
+		r = append(r, x-i)
+	}
+	return r
+}
+
+// Shrinks an array by first removing chunks of elements(half the array, then a quarter, and so on down to single elements)
+// and then by shrinking each element in place with the given element Shrinker.
+func ShrinkArray[T any](elem Shrinker[T]) Shrinker[[]T] {
+	return func(xs []T) [][]T {
+		var r [][]T
+		n := len(xs)
+		for k := n; k > 0; k = k / 2 {
+			for i := 0; i+k <= n; i = i + k {
+				c := make([]T, 0, n-k)
+				c = append(c, xs[:i]...)
+				c = append(c, xs[i+k:]...)
+				r = append(r, c)
+			}
+		}
+		if elem == nil {
+			return r
+		}
+		for i, x := range xs {
+			for _, s := range elem(x) {
+				c := make([]T, n)
+				copy(c, xs)
+				c[i] = s
+				r = append(r, c)
+			}
+		}
+		return r
+	}
+}
+
+// Shrinks a string by dropping runes, never splitting a multibyte code point.
+func ShrinkString(s string) []string {
+	var r []string
+	for _, c := range ShrinkArray[rune](nil)([]rune(s)) {
+		r = append(r, string(c))
+	}
+	return r
+}
+
+// Shrinks a Pair component-wise, first the A side and then the B side.
+func ShrinkPair[A, B any](sa Shrinker[A], sb Shrinker[B]) Shrinker[Pair[A, B]] {
+	return func(p Pair[A, B]) []Pair[A, B] {
+		var r []Pair[A, B]
+		if sa != nil {
+			for _, a := range sa(p.A) {
+				r = append(r, Pair[A, B]{a, p.B})
+			}
+		}
+		if sb != nil {
+			for _, b := range sb(p.B) {
+				r = append(r, Pair[A, B]{p.A, b})
+			}
+		}
+		return r
+	}
+}
+
+// Adapts a Shrinker of A to a Shrinker of B given a pair of conversion functions between the two types.
+// This is how you shrink the result of a MapN call: shrink the Pair(or nested Pairs) produced by Product and convert.
+func ShrinkConvert[A, B any](s Shrinker[A], to func(A) B, from func(B) A) Shrinker[B] {
+	return func(b B) []B {
+		var r []B
+		for _, a := range s(from(b)) {
+			r = append(r, to(a))
+		}
+		return r
+	}
+}
+
+// Greedily shrinks a failing value until no candidate fails or MaxShrinks steps have been taken.
+// Returns the locally minimal failing value, its errors, and the number of shrink steps taken. A Shrinker that panics ends shrinking.
+func shrink[A any](a A, errs error, s Shrinker[A], check func(A) error) (A, error, int) {
+	if s == nil {
+		return a, errs, 0
+	}
+	candidates := func(a A) (c []A) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("Panic:%v occurred in Shrinker for %v, shrinking stopped", err, a)
+				c = nil
+			}
+		}()
+		return s(a)
+	}
+	steps := 0
+	for steps < MaxShrinks {
+		shrunk := false
+		for _, c := range candidates(a) {
+			if err := check(c); err != nil {
+				a, errs = c, err
+				steps++
+				shrunk = true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return a, errs, steps
+}
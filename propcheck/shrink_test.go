@@ -0,0 +1,135 @@
+package propcheck
+
+import (
+	"fmt"
+	"github.com/go-test/deep"
+	"testing"
+	"time"
+)
+
+func TestShrinkIntMovesTowardZero(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseInt(-100000, 100000), "Every shrink candidate must be closer to zero than the original.",
+		func(x int) Pair[int, []int] { return Pair[int, []int]{x, ShrinkInt(x)} },
+		func(p Pair[int, []int]) (bool, error) {
+			abs := func(x int) int {
+				if x < 0 {
+					return -x
+				}
+				return x
+			}
+			for _, c := range p.B {
+				if abs(c) > abs(p.A) || c == p.A {
+					return false, fmt.Errorf("candidate %v was not smaller than %v", c, p.A)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	ExpectSuccess[int](t, result)
+}
+
+func TestShrinkIntOfZero(t *testing.T) {
+	if c := ShrinkInt(0); len(c) != 0 {
+		t.Errorf("Zero should have no shrink candidates but had %v", c)
+	}
+}
+
+func TestShrinkArrayRemovesChunksFirst(t *testing.T) {
+	actual := ShrinkArray(ShrinkInt)([]int{1, 2, 3, 4})
+	expected := [][]int{{}, {3, 4}, {1, 2}, {2, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 3}}
+	if diff := deep.Equal(actual[:len(expected)], expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestShrinkStringKeepsRunesIntact(t *testing.T) {
+	for _, c := range ShrinkString("aЖ界") {
+		for _, r := range c {
+			if r != 'a' && r != 'Ж' && r != '界' {
+				t.Errorf("Shrinking split a code point, got %q", c)
+			}
+		}
+	}
+}
+
+func TestShrinkPairShrinksEachComponent(t *testing.T) {
+	actual := ShrinkPair(ShrinkInt, NoShrink[string])(Pair[int, string]{2, "x"})
+	expected := []Pair[int, string]{{0, "x"}, {1, "x"}}
+	if diff := deep.Equal(actual, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestForAllShrinksToMinimalInt(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseInt(0, 1000000).WithShrinker(ShrinkInt), "Number must be less than 1000.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x >= 1000 {
+				return false, fmt.Errorf("%v was too large", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[int]:
+		if v.FailedCase != 1000 {
+			t.Errorf("Expected shrunk counterexample to be 1000 but was %v", v.FailedCase)
+		}
+		if v.Shrinks == 0 {
+			t.Errorf("Expected at least one shrink step")
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestForAllShrinksToMinimalArray(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := ForAll(ChooseArray(0, 200, ChooseInt(0, 100)).WithShrinker(ShrinkArray(ShrinkInt)), "Array must not contain a number greater than 50.",
+		func(xs []int) []int { return xs },
+		func(xs []int) (bool, error) {
+			for _, x := range xs {
+				if x > 50 {
+					return false, fmt.Errorf("%v was greater than 50", x)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[[]int]:
+		if diff := deep.Equal(v.FailedCase, []int{51}); diff != nil {
+			t.Errorf("Expected shrunk counterexample to be [51] but was %v", v.FailedCase)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
+
+func TestForAllShrinksToMinimalPair(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := Product(ChooseInt(0, 1000), String(40))
+	prop := ForAll(ge.WithShrinker(ShrinkPair(ShrinkInt, ShrinkString)), "Number must be small or string must be short.",
+		func(p Pair[int, string]) Pair[int, string] { return p },
+		func(p Pair[int, string]) (bool, error) {
+			if p.A > 10 && len(p.B) > 2 {
+				return false, fmt.Errorf("%v was too big", p)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	switch v := result.(type) {
+	case Falsified[Pair[int, string]]:
+		if v.FailedCase.A != 11 || len(v.FailedCase.B) != 3 {
+			t.Errorf("Expected shrunk counterexample to be {11, <3 characters>} but was %v", v.FailedCase)
+		}
+	default:
+		t.Errorf("Expected property to be falsified but was %v", v)
+	}
+}
@@ -0,0 +1,49 @@
+package propcheck
+
+type Size = int //A hint to A generator about how big the values it generates should be.
+
+// The largest Size given to generators when RunParms.MaxSize is not set.
+const DefaultMaxSize Size = 100
+
+// Makes A Gen whose behavior depends upon the Size it is run with. ForAll grows the Size from zero for the first test case
+// up to RunParms.MaxSize for the last one, so early test cases exercise empty and small values and later ones stress large values.
+func Sized[A any](f func(Size) Gen[A]) Gen[A] {
+	return genWithSize(func(rng RNG, size Size) (A, RNG) {
+		return f(size).run(rng, size)
+	})
+}
+
+// Returns A copy of the Gen that always runs with the given Size, no matter what Size it is given.
+func Resize[A any](size Size, g Gen[A]) Gen[A] {
+	r := g
+	r.run = func(rng RNG, _ Size) (A, RNG) {
+		return g.run(rng, size)
+	}
+	return r
+}
+
+// Generates A string of between zero and Size code points from the same character set as String.
+func SizedString() Gen[string] {
+	return Sized(func(size Size) Gen[string] {
+		return String(size + 1)
+	}).WithShrinker(ShrinkString)
+}
+
+// Generates an array of between zero and Size elements using the given Gen.
+// The Gen shrinks by removing elements and by shrinking elements with the Shrinker of kind.
+func SizedArray[T any](kind Gen[T]) Gen[[]T] {
+	return Sized(func(size Size) Gen[[]T] {
+		return ChooseArray(0, size+1, kind)
+	}).WithShrinker(ShrinkArray(kind.shrink))
+}
+
+// The Size to use for test case x of n, growing linearly from zero to maxSize.
+func sizeFor(x int, n TestCases, maxSize Size) Size {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if n <= 1 {
+		return maxSize
+	}
+	return x * maxSize / (n - 1)
+}
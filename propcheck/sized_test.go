@@ -0,0 +1,108 @@
+package propcheck
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSizeGrowsAcrossTestCases(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var sizes []Size
+	ge := Sized(func(size Size) Gen[int] {
+		sizes = append(sizes, size)
+		return Id(size)
+	})
+	prop := ForAll(ge, "Size must never exceed MaxSize.",
+		func(x int) int { return x },
+		func(x int) (bool, error) {
+			if x > 50 {
+				return false, fmt.Errorf("size %v exceeded the max size", x)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 100, Rng: rng, MaxSize: 50})
+	ExpectSuccess[int](t, result)
+	if sizes[0] != 0 || sizes[len(sizes)-1] != 50 {
+		t.Errorf("Size should have grown from 0 to 50 but went from %v to %v", sizes[0], sizes[len(sizes)-1])
+	}
+	for i := 1; i < len(sizes); i++ {
+		if sizes[i] < sizes[i-1] {
+			t.Errorf("Size should never shrink between test cases but went from %v to %v", sizes[i-1], sizes[i])
+		}
+	}
+}
+
+func TestDefaultMaxSize(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	size, _ := Sized(func(size Size) Gen[int] { return Id(size) }).Run(rng)
+	if size != DefaultMaxSize {
+		t.Errorf("Run should have used a Size of %v but used %v", DefaultMaxSize, size)
+	}
+}
+
+func TestResize(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	ge := Resize(3, SizedArray(Int()))
+	prop := ForAll(ge, "Array must have at most 3 elements.",
+		func(xs []int) []int { return xs },
+		func(xs []int) (bool, error) {
+			if len(xs) > 3 {
+				return false, fmt.Errorf("array was too long: %v", xs)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng, MaxSize: 1000})
+	ExpectSuccess[[]int](t, result)
+}
+
+func TestSizedStringStartsEmptyAndRespectsSize(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	maxSize := 20
+	var first *string
+	prop := ForAll(SizedString(), "String must not have more code points than the max size.",
+		func(s string) string {
+			if first == nil {
+				first = &s
+			}
+			return s
+		},
+		func(s string) (bool, error) {
+			if len([]rune(s)) > maxSize {
+				return false, fmt.Errorf("string was too long: %v", s)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng, MaxSize: maxSize})
+	ExpectSuccess[string](t, result)
+	if *first != "" {
+		t.Errorf("The first test case has a Size of zero and should have been the empty string but was %v", *first)
+	}
+}
+
+func TestSizedArrayGetsLargerInLaterTestCases(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	var longest int
+	prop := ForAll(SizedArray(ChooseInt(0, 10)), "Array must not be longer than the max size.",
+		func(xs []int) []int {
+			if len(xs) > longest {
+				longest = len(xs)
+			}
+			return xs
+		},
+		func(xs []int) (bool, error) {
+			if len(xs) > 300 {
+				return false, fmt.Errorf("array was too long: %v", len(xs))
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng, MaxSize: 300})
+	ExpectSuccess[[]int](t, result)
+	if longest < 100 {
+		t.Errorf("Later test cases should have produced long arrays but the longest was %v", longest)
+	}
+}
@@ -0,0 +1,129 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The number of times StateMachine.Prop tries to generate A command whose precondition holds before it ends A command sequence early.
+const MaxCommandTries = 100
+
+/*
+*
+A Command is one step of A stateful property. It is run against the real system under test of type S and checked against A model
+of type M, A simple and obviously correct version of the system such as A sorted slice standing in for A heap.
+
+Commands are values, usually small structs holding the arguments of the operation, and are printed with %v in A failure report,
+so give them A String method if their fields alone are not descriptive.
+*/
+type Command[S, M any] interface {
+	// Whether the command may run when the model is in state m, e.g. "the model is not empty" for A delete.
+	PreCondition(m M) bool
+	// Runs the command against the system and returns its result, if any.
+	Run(s S) any
+	// Checks the result of Run against the model state before the command ran. Returns A descriptive error if the result is wrong.
+	PostCondition(m M, result any) error
+	// The model state after the command runs in state m.
+	NextState(m M) M
+}
+
+/*
+*
+A StateMachine describes A stateful property. Its Prop generates random sequences of commands, runs each sequence against
+A new system and model, and is falsified if the postcondition of any command fails.
+
+Fields:
+
+	InitialModel - makes the model state that every command sequence starts from.
+	NewSystem - makes A new system under test in the state matching InitialModel.
+	Commands - makes A Gen of the commands that may be useful in model state m. Commands whose preconditions do not hold are regenerated.
+*/
+type StateMachine[S, M any] struct {
+	InitialModel func() M
+	NewSystem    func() S
+	Commands     func(m M) Gen[Command[S, M]]
+}
+
+// A sequence of commands in the order they run.
+type CommandSequence[S, M any] []Command[S, M]
+
+func (w CommandSequence[S, M]) String() string {
+	var r []string
+	for _, c := range w {
+		r = append(r, fmt.Sprintf("%v", c))
+	}
+	return "[" + strings.Join(r, ", ") + "]"
+}
+
+/*
+*
+Returns A Gen of command sequences of between zero and Size commands whose preconditions all hold when run in order from
+the initial model state. The Gen shrinks by removing commands, keeping only the candidates whose preconditions still hold.
+*/
+func (sm StateMachine[S, M]) Gen() Gen[CommandSequence[S, M]] {
+	g := genWithSize(func(rng RNG, size Size) (CommandSequence[S, M], RNG) {
+		var n int
+		n, rng = rng.Intn(size + 1)
+		var r CommandSequence[S, M]
+		m := sm.InitialModel()
+		for len(r) < n {
+			c, ok := Command[S, M](nil), false
+			for x := 0; x < MaxCommandTries && !ok; x++ {
+				c, rng = sm.Commands(m).run(rng, size)
+				ok = c.PreCondition(m)
+			}
+			if !ok {
+				break
+			}
+			r = append(r, c)
+			m = c.NextState(m)
+		}
+		return r, rng
+	})
+	removeCommands := ShrinkConvert(ShrinkArray[Command[S, M]](nil),
+		func(cs []Command[S, M]) CommandSequence[S, M] { return cs },
+		func(cs CommandSequence[S, M]) []Command[S, M] { return cs },
+	)
+	return g.WithShrinker(filterShrinker(removeCommands, sm.valid))
+}
+
+// Whether the preconditions of all the commands hold when they run in order from the initial model state.
+func (sm StateMachine[S, M]) valid(cs CommandSequence[S, M]) bool {
+	m := sm.InitialModel()
+	for _, c := range cs {
+		if !c.PreCondition(m) {
+			return false
+		}
+		m = c.NextState(m)
+	}
+	return true
+}
+
+// Runs the commands in order against A new system and returns an error for the first command whose postcondition fails.
+func (sm StateMachine[S, M]) run(cs CommandSequence[S, M]) error {
+	s := sm.NewSystem()
+	m := sm.InitialModel()
+	for i, c := range cs {
+		result := c.Run(s)
+		if err := c.PostCondition(m, result); err != nil {
+			return fmt.Errorf("command %v %v returned %v: %w", i+1, c, result, err)
+		}
+		m = c.NextState(m)
+	}
+	return nil
+}
+
+/*
+*
+Returns A Prop that runs the command sequences of Gen against A new system each and is falsified when the postcondition of
+A command fails. The counterexample of A falsified Prop is the shortest failing command sequence shrinking found, and its error
+names the command that failed.
+
+It is an ordinary ForAll property, so it can be run with Check or Prop.Run and combined with other properties.
+*/
+func (sm StateMachine[S, M]) Prop(name string) Prop {
+	return ForAll(sm.Gen(), name,
+		func(cs CommandSequence[S, M]) error { return sm.run(cs) },
+		func(err error) (bool, error) { return err == nil, err },
+	)
+}
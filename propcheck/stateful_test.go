@@ -0,0 +1,98 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A counter that forgets increments once it reaches its limit, modelled by an int.
+type counter struct {
+	n     int
+	limit int
+}
+
+type increment struct{}
+
+func (increment) PreCondition(int) bool { return true }
+func (increment) Run(c *counter) any {
+	if c.n < c.limit {
+		c.n++
+	}
+	return nil
+}
+func (increment) PostCondition(int, any) error { return nil }
+func (increment) NextState(m int) int          { return m + 1 }
+func (increment) String() string               { return "increment" }
+
+type decrement struct{}
+
+func (decrement) PreCondition(m int) bool { return m > 0 }
+func (decrement) Run(c *counter) any {
+	c.n--
+	return nil
+}
+func (decrement) PostCondition(int, any) error { return nil }
+func (decrement) NextState(m int) int          { return m - 1 }
+func (decrement) String() string               { return "decrement" }
+
+type get struct{}
+
+func (get) PreCondition(int) bool { return true }
+func (get) Run(c *counter) any    { return c.n }
+func (get) PostCondition(m int, result any) error {
+	if result != m {
+		return fmt.Errorf("expected %v", m)
+	}
+	return nil
+}
+func (get) NextState(m int) int { return m }
+func (get) String() string      { return "get" }
+
+func counterMachine(limit int) StateMachine[*counter, int] {
+	commands := []Command[*counter, int]{increment{}, increment{}, decrement{}, get{}}
+	return StateMachine[*counter, int]{
+		InitialModel: func() int { return 0 },
+		NewSystem:    func() *counter { return &counter{limit: limit} },
+		Commands: func(int) Gen[Command[*counter, int]] {
+			return Map(ChooseInt(0, len(commands)), func(i int) Command[*counter, int] { return commands[i] })
+		},
+	}
+}
+
+func TestStateMachinePasses(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	result := counterMachine(1000).Prop("A counter counts").Run(RunParms{TestCases: 100, Rng: rng})
+	ExpectSuccess[CommandSequence[*counter, int]](t, result)
+}
+
+func TestStateMachineShrinksFailingSequence(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	result := counterMachine(2).Prop("A counter counts").Run(RunParms{TestCases: 200, Rng: rng})
+	f, ok := result.(Falsified[CommandSequence[*counter, int]])
+	if !ok {
+		t.Fatalf("Property should have been falsified but was %v", result)
+	}
+	if actual := f.FailedCase.String(); actual != "[increment, increment, increment, get]" {
+		t.Errorf("Failing sequence should have shrunk to three increments and a get but was %v", actual)
+	}
+	if !strings.Contains(f.Errors.Error(), "command 4 get returned 2: expected 3") {
+		t.Errorf("Errors should have named the failing command but were %v", f.Errors)
+	}
+}
+
+func TestStateMachineGenSatisfiesPreConditions(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	sm := counterMachine(1000)
+	for _, cs := range sm.Gen().Sample(rng, 50) {
+		if !sm.valid(cs) {
+			t.Errorf("%v decremented an empty counter", cs)
+		}
+		for _, c := range sm.Gen().Shrinker()(cs) {
+			if !sm.valid(c) {
+				t.Errorf("Shrink candidate %v decremented an empty counter", c)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package propcheck
+
+import (
+	"fmt"
+	"github.com/hashicorp/go-multierror"
+	"sort"
+)
+
+type coverRequirement[A any] struct {
+	minPercent float64
+	label      string
+}
+
+// Returns A copy of the Gen that labels every generated value satisfying cond. ForAll counts the test cases with each label
+// and reports the counts in Passed.Labels, so you can see the distribution of test data A property actually exercised.
+// Like the other extras of A Gen, labels are only counted for the Gen passed directly to ForAll. They are kept by the methods and
+// functions that return A Gen of the same values, such as WithShrinker, WithLabel, Filter, SuchThat and Resize, but lost by those that
+// make A Gen of new values, such as Map, Product, FlatMap and OneOf, so call Classify after them, last before ForAll.
+func (g Gen[A]) Classify(cond func(A) bool, label string) Gen[A] {
+	return g.Collect(func(a A) string {
+		if cond(a) {
+			return label
+		}
+		return ""
+	})
+}
+
+// Returns A copy of the Gen that labels every generated value with the result of f. An empty label is not counted.
+// See Classify, including for which combinators keep the labels.
+func (g Gen[A]) Collect(f func(A) string) Gen[A] {
+	g.classify = append(g.classify[:len(g.classify):len(g.classify)], f)
+	return g
+}
+
+// Returns A copy of the Gen that labels every generated value satisfying cond and requires at least minPercent of the test cases
+// to have the label. ForAll falsifies the property when the requirement is not met, which catches generators that rarely produce
+// the values A property needs. Map and the other combinators that make A Gen of new values drop the requirement, see Classify, so
+// Map(g.Cover(...), f) never checks coverage; cover the mapped Gen instead.
+func (g Gen[A]) Cover(minPercent float64, cond func(A) bool, label string) Gen[A] {
+	g = g.Classify(cond, label)
+	g.cover = append(g.cover[:len(g.cover):len(g.cover)], coverRequirement[A]{minPercent, label})
+	return g
+}
+
+// Counts the labels of all successfully generated test cases.
+func classify[A any](ge Gen[A], cases []testCase[A]) map[string]int {
+	if len(ge.classify) == 0 {
+		return nil
+	}
+	labels := map[string]int{}
+	for _, c := range cases {
+		if !c.generated || c.err != nil {
+			continue
+		}
+		for _, f := range ge.classify {
+			if l := f(c.input); l != "" {
+				labels[l]++
+			}
+		}
+	}
+	return labels
+}
+
+// Returns an error for every coverage requirement of the Gen that was not met.
+func checkCoverage[A any](ge Gen[A], labels map[string]int, testCases int) error {
+	var errors error
+	for _, c := range ge.cover {
+		p := percent(labels[c.label], testCases)
+		if p < c.minPercent {
+			errors = multierror.Append(errors, fmt.Errorf("insufficient coverage: %q was %.1f%% of test cases but %.1f%% was required", c.label, p, c.minPercent))
+		}
+	}
+	return errors
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
+
+// Formats labels as percentages of the test cases, most frequent first.
+func histogram(labels map[string]int, testCases int) []string {
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if labels[keys[i]] != labels[keys[j]] {
+			return labels[keys[i]] > labels[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	var r []string
+	for _, k := range keys {
+		r = append(r, fmt.Sprintf("%.1f%% %v", percent(labels[k], testCases), k))
+	}
+	return r
+}
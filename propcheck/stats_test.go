@@ -0,0 +1,87 @@
+package propcheck
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func alwaysTrue(int) (bool, error) {
+	return true, nil
+}
+
+func TestClassifyCountsLabels(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseInt(0, 1000).
+		Classify(func(x int) bool { return x < 500 }, "small").
+		Classify(func(x int) bool { return x%2 == 0 }, "even")
+	result := ForAll(g, "Classified numbers", func(x int) int { return x }, alwaysTrue).Run(RunParms{TestCases: 200, Rng: rng})
+	passed, ok := result.(Passed[int])
+	if !ok {
+		t.Fatalf("Property should have passed but was %v", result)
+	}
+	if passed.TestCases != 200 {
+		t.Errorf("Passed should have counted 200 test cases but counted %v", passed.TestCases)
+	}
+	small, even := passed.Labels["small"], passed.Labels["even"]
+	if small == 0 || small == 200 || even == 0 || even == 200 {
+		t.Errorf("Labels should have been counted for some but not all test cases but were %v", passed.Labels)
+	}
+	if !strings.Contains(passed.String(), "% small") {
+		t.Errorf("Passed should have shown the labels but was %v", passed)
+	}
+}
+
+func TestCollectCountsEveryLabel(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseInt(0, 3).Collect(func(x int) string {
+		return []string{"zero", "one", "two"}[x]
+	})
+	result := ForAll(g, "Collected numbers", func(x int) int { return x }, alwaysTrue).Run(RunParms{TestCases: 300, Rng: rng})
+	passed := result.(Passed[int])
+	total := 0
+	for _, n := range passed.Labels {
+		total += n
+	}
+	if len(passed.Labels) != 3 || total != 300 {
+		t.Errorf("Every test case should have been labelled zero, one or two but the labels were %v", passed.Labels)
+	}
+}
+
+func TestLabelsSurviveFilterAndResize(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := Resize(10, ChooseInt(0, 1000).Classify(func(int) bool { return true }, "all").Filter(func(x int) bool { return x > 0 }))
+	result := ForAll(g, "Filtered numbers", func(x int) int { return x }, alwaysTrue).Run(RunParms{TestCases: 50, Rng: rng})
+	if n := result.(Passed[int]).Labels["all"]; n != 50 {
+		t.Errorf("All 50 test cases should have been labelled but %v were", n)
+	}
+}
+
+func TestCoverSurvivesMethodsThatKeepTheValues(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseInt(0, 1000).Cover(50, func(x int) bool { return x < 10 }, "tiny").
+		WithShrinker(ShrinkInt).WithLabel("numbers").SuchThat(func(x int) bool { return x >= 0 })
+	if result := ForAll(g, "Tiny numbers", func(x int) int { return x }, alwaysTrue).Run(RunParms{TestCases: 100, Rng: rng}); !result.IsFalsified() {
+		t.Errorf("The coverage requirement should have survived and falsified the property but the result was %v", result)
+	}
+}
+
+func TestCoverFalsifiesInsufficientCoverage(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseArray(0, 200, Int()).Cover(10, func(xs []int) bool { return len(xs) == 0 }, "empty")
+	prop := ForAll(g, "Arrays are rarely empty", func(xs []int) []int { return xs },
+		func(xs []int) (bool, error) { return true, nil },
+	)
+	result := prop.Run(RunParms{TestCases: 200, Rng: rng})
+	ExpectFailure[[]int](t, result)
+	if f, ok := result.(Falsified[[]int]); !ok || !strings.Contains(f.Errors.Error(), `insufficient coverage: "empty"`) {
+		t.Errorf("Property should have been falsified for insufficient coverage but was %v", result)
+	}
+}
+
+func TestCoverPassesWithSufficientCoverage(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := ChooseInt(0, 1000).Cover(30, func(x int) bool { return x < 500 }, "small")
+	result := ForAll(g, "Half the numbers are small", func(x int) int { return x }, alwaysTrue).Run(RunParms{TestCases: 200, Rng: rng})
+	ExpectSuccess[int](t, result)
+}
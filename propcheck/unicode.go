@@ -0,0 +1,200 @@
+package propcheck
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The emoji blocks: Miscellaneous Symbols, Dingbats and the supplementary emoji planes from Miscellaneous Symbols and Pictographs
+// to Symbols and Pictographs Extended-A.
+var Emoji = &unicode.RangeTable{
+	R16: []unicode.Range16{{Lo: 0x2600, Hi: 0x27BF, Stride: 1}},
+	R32: []unicode.Range32{{Lo: 0x1F300, Hi: 0x1FAFF, Stride: 1}},
+}
+
+// Code points that commonly break text handling: NUL, control characters, the byte order mark, zero-width and bidirectional
+// formatting characters, the replacement character, the code points on either side of the surrogates, the last code point of
+// the Basic Multilingual Plane, the first supplementary code point and the largest code point.
+var specialRunes = []rune{0, '\t', '\n', '\r', 0x7F, 0x80, 0xA0, 0xAD, 0x200B, 0x200D, 0x200E, 0x200F, 0x2028, 0xFEFF, 0xFFFD,
+	0xD7FF, 0xE000, 0xFFFF, 0x10000, unicode.MaxRune}
+
+// The code points of A list of unicode.RangeTables, indexed so that A random one can be drawn in logarithmic time.
+type runeTable struct {
+	ranges []runeRange
+	total  int
+}
+
+type runeRange struct {
+	lo, hi, stride rune
+	first          int //The index of lo in the table.
+}
+
+func newRuneTable(tables ...*unicode.RangeTable) runeTable {
+	var t runeTable
+	add := func(lo, hi, stride rune) {
+		t.ranges = append(t.ranges, runeRange{lo, hi, stride, t.total})
+		t.total += int((hi-lo)/stride) + 1
+	}
+	for _, table := range tables {
+		for _, r := range table.R16 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range table.R32 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+	}
+	return t
+}
+
+func (t runeTable) at(i int) rune {
+	if i < 0 || i >= t.total {
+		panic(fmt.Sprintf("Index %v was outside A table of %v runes", i, t.total))
+	}
+	//The last range that starts at or before i.
+	r := t.ranges[sort.Search(len(t.ranges), func(x int) bool { return t.ranges[x].first > i })-1]
+	return r.lo + rune(i-r.first)*r.stride
+}
+
+// The index of the first occurrence of c in the table, or -1.
+func (t runeTable) index(c rune) int {
+	for _, r := range t.ranges {
+		if c >= r.lo && c <= r.hi && (c-r.lo)%r.stride == 0 {
+			return r.first + int((c-r.lo)/r.stride)
+		}
+	}
+	return -1
+}
+
+// Generates A rune from the code points in the given tables, such as unicode.Latin, unicode.Han, unicode.Mn or unicode.Digit.
+// Every code point in the tables is equally likely. The Gen shrinks toward the first code point of the first table.
+func RuneFrom(tables ...*unicode.RangeTable) Gen[rune] {
+	t := newRuneTable(tables...)
+	if t.total == 0 {
+		panic("RuneFrom needs at least one code point")
+	}
+	g := NewGen(func(rng RNG) (rune, RNG) {
+		i, r := rng.Intn(t.total)
+		return t.at(i), r
+	})
+	return g.WithShrinker(func(c rune) []rune {
+		var r []rune
+		if i := t.index(c); i > 0 {
+			for _, j := range ShrinkInt(i) {
+				r = append(r, t.at(j))
+			}
+		}
+		return r
+	})
+}
+
+// Generates A rune between lo and hi inclusive.
+func RuneRange(lo, hi rune) Gen[rune] {
+	return RuneFrom(&unicode.RangeTable{R32: []unicode.Range32{{Lo: uint32(lo), Hi: uint32(hi), Stride: 1}}})
+}
+
+// Generates A string of between zero and Size runes from the given Gen. The Gen shrinks by removing runes and by shrinking runes
+// with the Shrinker of runes.
+func StringOf(runes Gen[rune]) Gen[string] {
+	return Map(SizedArray(runes), func(rs []rune) string { return string(rs) }).
+		WithShrinker(ShrinkConvert(ShrinkArray(runes.shrink), func(rs []rune) string { return string(rs) }, func(s string) []rune { return []rune(s) }))
+}
+
+// Generates A letter or digit from 0-9, a-z and A-Z.
+func AlphanumericRune() Gen[rune] {
+	return RuneFrom(&unicode.RangeTable{R16: []unicode.Range16{{Lo: 'a', Hi: 'z', Stride: 1}, {Lo: '0', Hi: '9', Stride: 1}, {Lo: 'A', Hi: 'Z', Stride: 1}}})
+}
+
+// Generates A string of between zero and Size letters and digits from 0-9, a-z and A-Z.
+func Alphanumeric() Gen[string] {
+	return StringOf(AlphanumericRune())
+}
+
+// Generates A string of between zero and Size printable ASCII characters, space to tilde.
+func ASCIIPrintable() Gen[string] {
+	return StringOf(RuneRange(' ', '~'))
+}
+
+// Generates A Go identifier of between one and Size+1 runes: A Unicode letter or underscore followed by letters, digits and underscores.
+// The Gen shrinks to shorter identifiers.
+func Identifier() Gen[string] {
+	underscore := &unicode.RangeTable{R16: []unicode.Range16{{Lo: '_', Hi: '_', Stride: 1}}}
+	first := RuneFrom(unicode.Letter, underscore)
+	rest := StringOf(RuneFrom(unicode.Letter, unicode.Digit, underscore))
+	g := Map2(first, rest, func(f rune, r string) string { return string(f) + r })
+	return g.WithShrinker(filterShrinker(ShrinkString, isIdentifier))
+}
+
+func isIdentifier(s string) bool {
+	for i, c := range s {
+		if !(unicode.IsLetter(c) || c == '_' || i > 0 && unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Generates A combining mark, such as A combining accent, from the Unicode category Mn.
+func CombiningMark() Gen[rune] {
+	return RuneFrom(unicode.Mn)
+}
+
+// Generates an emoji from the Emoji table.
+func EmojiRune() Gen[rune] {
+	return RuneFrom(Emoji)
+}
+
+/*
+*
+Generates A Unicode code point that is valid in A UTF-8 string, i.e. any code point but A surrogate. Most are graphic characters
+from unicode.GraphicRanges, in every script, but one time in EdgeCaseOdds it draws A code point that commonly breaks text handling:
+control characters, the byte order mark, zero-width and bidirectional formatting characters, the code points adjacent to the surrogates
+and the largest code point. Combining marks and emoji come up often as they are graphic characters.
+*/
+func UnicodeRune() Gen[rune] {
+	graphic := RuneFrom(unicode.GraphicRanges...)
+	g := NewGen(func(rng RNG) (rune, RNG) {
+		if c, r, ok := edgeCase(rng, specialRunes); ok {
+			return c, r
+		}
+		return graphic.Run(rng)
+	})
+	return g.WithShrinker(func(c rune) []rune {
+		if c != 'a' {
+			return []rune{'a'}
+		}
+		return nil
+	})
+}
+
+// Generates A valid UTF-8 string of between zero and Size runes from UnicodeRune.
+func UnicodeString() Gen[string] {
+	return StringOf(UnicodeRune())
+}
+
+// Byte sequences that are not valid UTF-8: A lone continuation byte, an overlong encoding of '/', an encoded surrogate,
+// A truncated three-byte sequence, bytes that never occur in UTF-8 and A code point beyond unicode.MaxRune.
+var invalidUTF8 = []string{"\x80", "\xC0\xAF", "\xED\xA0\x80", "\xE2\x82", "\xFE", "\xFF", "\xF4\x90\x80\x80"}
+
+// Generates A string that is not valid UTF-8: A UnicodeString with an invalid byte sequence inserted at A random position.
+// The Gen shrinks to shorter strings that are still not valid UTF-8.
+func InvalidUTF8() Gen[string] {
+	valid := UnicodeString()
+	g := genWithSize(func(rng RNG, size Size) (string, RNG) {
+		s, r := valid.run(rng, size)
+		rs := []rune(s)
+		at, r := r.Intn(len(rs) + 1)
+		bad, r := r.Intn(len(invalidUTF8))
+		return string(rs[:at]) + invalidUTF8[bad] + string(rs[at:]), r
+	})
+	return g.WithShrinker(func(s string) []string {
+		var r []string
+		for _, c := range ShrinkArray[byte](nil)([]byte(s)) {
+			if !utf8.Valid(c) {
+				r = append(r, string(c))
+			}
+		}
+		return r
+	})
+}
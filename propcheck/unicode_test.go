@@ -0,0 +1,119 @@
+package propcheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestStringUsesOnlyAlphanumerics(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, s := range String(50).Sample(rng, 200) {
+		if strings.Trim(s, alphanumerics) != "" {
+			t.Errorf("%q should only have contained 0-9, a-z and A-Z", s)
+		}
+	}
+}
+
+func TestRuneFromDrawsFromTables(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	g := RuneFrom(unicode.Greek, unicode.Cyrillic)
+	var greek, cyrillic int
+	for _, c := range g.Sample(rng, 500) {
+		switch {
+		case unicode.Is(unicode.Greek, c):
+			greek++
+		case unicode.Is(unicode.Cyrillic, c):
+			cyrillic++
+		default:
+			t.Errorf("%q was neither Greek nor Cyrillic", c)
+		}
+	}
+	if greek == 0 || cyrillic == 0 {
+		t.Errorf("Both tables should have been used but there were %v Greek and %v Cyrillic runes", greek, cyrillic)
+	}
+	for _, c := range g.Shrinker()('Ж') {
+		if !unicode.Is(unicode.Greek, c) && !unicode.Is(unicode.Cyrillic, c) {
+			t.Errorf("Shrink candidate %q was outside the tables", c)
+		}
+	}
+}
+
+func TestRuneRangeIsInclusive(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	seen := map[rune]bool{}
+	for _, c := range RuneRange('a', 'c').Sample(rng, 100) {
+		seen[c] = true
+	}
+	if len(seen) != 3 || !seen['a'] || !seen['c'] {
+		t.Errorf("RuneRange('a', 'c') should have produced a, b and c but produced %v", seen)
+	}
+}
+
+func TestStringFamilies(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	checks := []struct {
+		name  string
+		g     Gen[string]
+		valid func(string) bool
+	}{
+		{"Alphanumeric", Alphanumeric(), func(s string) bool { return strings.Trim(s, alphanumerics) == "" }},
+		{"ASCIIPrintable", ASCIIPrintable(), func(s string) bool {
+			return strings.IndexFunc(s, func(c rune) bool { return c < ' ' || c > '~' }) < 0
+		}},
+		{"Identifier", Identifier(), isIdentifier},
+		{"UnicodeString", UnicodeString(), utf8.ValidString},
+		{"InvalidUTF8", InvalidUTF8(), func(s string) bool { return !utf8.ValidString(s) }},
+	}
+	for _, c := range checks {
+		for _, s := range c.g.Sample(rng, 100) {
+			if !c.valid(s) {
+				t.Errorf("%v produced %q", c.name, s)
+			}
+			for _, shrunk := range c.g.Shrinker()(s) {
+				if !c.valid(shrunk) {
+					t.Errorf("%v shrank %q to %q", c.name, s, shrunk)
+				}
+			}
+		}
+	}
+}
+
+func TestUnicodeStringCoversRealWorldText(t *testing.T) {
+	rng := SimpleRNG{Seed: 13634551} //A fixed seed, so that the coverage thresholds cannot fail by chance.
+	prop := ForAll(UnicodeRune().Classify(func(c rune) bool { return c > unicode.MaxLatin1 }, "beyond Latin-1").
+		Classify(func(c rune) bool { return c > 0xFFFF }, "supplementary").
+		Classify(func(c rune) bool { return unicode.Is(unicode.Mn, c) }, "combining").
+		Classify(func(c rune) bool { return contains(specialRunes, c) }, "special").
+		Cover(50, func(c rune) bool { return c > unicode.MaxLatin1 }, "beyond Latin-1").
+		Cover(1, func(c rune) bool { return contains(specialRunes, c) }, "special").
+		Cover(0.5, func(c rune) bool { return unicode.Is(unicode.Mn, c) }, "combining"),
+		"Runes are valid code points",
+		func(c rune) rune { return c },
+		func(c rune) (bool, error) {
+			if !utf8.ValidRune(c) {
+				return false, fmt.Errorf("%U was not valid", c)
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(RunParms{TestCases: 2000, Rng: rng})
+	ExpectSuccess[rune](t, result)
+}
+
+func TestEmojiAndCombiningMarks(t *testing.T) {
+	rng := SimpleRNG{Seed: time.Now().Nanosecond()}
+	for _, c := range EmojiRune().Sample(rng, 50) {
+		if !unicode.Is(Emoji, c) {
+			t.Errorf("%U was not an emoji", c)
+		}
+	}
+	for _, c := range CombiningMark().Sample(rng, 50) {
+		if !unicode.Is(unicode.Mn, c) {
+			t.Errorf("%U was not a combining mark", c)
+		}
+	}
+}
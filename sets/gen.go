@@ -9,10 +9,10 @@ import (
 // eq - a predicate function that returns true if l is equal to r
 // Note that the returned set may not meet the minimum size requirement after de-duplication.
 // This is impossible to handle in any general way(i.e. the set of bools with cardinality == 3).
-func ChooseSet[T any](start, stopInclusive int, kind func(pair propcheck.SimpleRNG) (T, propcheck.SimpleRNG), lt func(l, r T) bool, eq func(l, r T) bool) func(propcheck.SimpleRNG) ([]T, propcheck.SimpleRNG) {
-	return func(rng propcheck.SimpleRNG) ([]T, propcheck.SimpleRNG) {
+func ChooseSet[T any](start, stopInclusive int, kind propcheck.Gen[T], lt func(l, r T) bool, eq func(l, r T) bool) propcheck.Gen[[]T] {
+	return propcheck.NewGen(func(rng propcheck.RNG) ([]T, propcheck.RNG) {
 		r := propcheck.ChooseArray(start, stopInclusive, kind)
-		s, rng2 := r(rng)
+		s, rng2 := r.Run(rng)
 		return ToSet(s, lt, eq), rng2
-	}
+	})
 }
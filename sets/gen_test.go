@@ -51,6 +51,6 @@ func TestChooseSet(t *testing.T) {
 		},
 	)
 	bigProp := propcheck.And[[]int](lengthGEOne, lengthLEMax)
-	result := bigProp.Run(propcheck.RunParms{100, rng})
+	result := bigProp.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]bool](t, result)
 }
@@ -71,7 +71,7 @@ func TestToSet(t *testing.T) {
 		arrayToFancyType,
 		setCorrectLength, setComplete,
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -64,7 +64,7 @@ func TestQuickSortWithInts(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 
 }
@@ -123,7 +123,7 @@ func TestQuickSortWithStrings(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -0,0 +1,17 @@
+package stack
+
+import (
+	"github.com/greymatter-io/golangz/propcheck"
+)
+
+// Generates A stack of between zero and Size elements using the given Gen.
+// The Gen shrinks by removing elements and by shrinking elements with the Shrinker of kind.
+func StackOf[A any](kind propcheck.Gen[A]) propcheck.Gen[Stack[A]] {
+	xs := propcheck.SizedArray(kind)
+	return propcheck.Map(xs, FromArray[A]).WithShrinker(propcheck.ShrinkConvert(xs.Shrinker(), FromArray[A], toArray[A]))
+}
+
+// The elements of the stack, top first, so that FromArray(toArray(s)) is the same stack.
+func toArray[A any](s Stack[A]) []A {
+	return FoldLeft(s, []A{}, func(accum []A, a A) []A { return append(accum, a) })
+}
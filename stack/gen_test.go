@@ -0,0 +1,36 @@
+package stack
+
+import (
+	"fmt"
+	"github.com/greymatter-io/golangz/propcheck"
+	"testing"
+	"time"
+)
+
+func TestStackOfShrinks(t *testing.T) {
+	rng := propcheck.SimpleRNG{Seed: time.Now().Nanosecond()}
+	prop := propcheck.ForAll(StackOf(propcheck.ChooseInt(0, 100)), "Stacks have no element over 50",
+		func(s Stack[int]) Stack[int] { return s },
+		func(s Stack[int]) (bool, error) {
+			for _, x := range toArray(s) {
+				if x > 50 {
+					return false, fmt.Errorf("%v was over 50", x)
+				}
+			}
+			return true, nil
+		},
+	)
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
+	propcheck.ExpectFailure[Stack[int]](t, result)
+	f := result.(propcheck.Falsified[Stack[int]])
+	if xs := toArray(f.FailedCase); len(xs) != 1 || xs[0] != 51 {
+		t.Errorf("The counterexample should have shrunk to a stack of 51 but was %v", xs)
+	}
+}
+
+func TestStackOfPreservesOrder(t *testing.T) {
+	xs := []int{1, 2, 3}
+	if ys := toArray(FromArray(xs)); fmt.Sprint(ys) != fmt.Sprint(xs) {
+		t.Errorf("toArray(FromArray(%v)) should have been %v but was %v", xs, xs, ys)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/greymatter-io/golangz/propcheck"
 	"github.com/hashicorp/go-multierror"
 	"math"
+	"sync"
 	"testing"
 	"time"
 )
@@ -44,7 +45,7 @@ func TestPush(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -91,7 +92,7 @@ func TestPop(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]string](t, result)
 }
 
@@ -160,7 +161,7 @@ func TestFoldRight(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -204,7 +205,7 @@ func TestFoldLeft(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
 
@@ -247,6 +248,79 @@ func TestFromArray(t *testing.T) {
 			}
 		},
 	)
-	result := prop.Run(propcheck.RunParms{100, rng})
+	result := prop.Run(propcheck.RunParms{TestCases: 100, Rng: rng})
 	propcheck.ExpectSuccess[[]int](t, result)
 }
+
+// A Stack shared between Go Routines by guarding it with a mutex, the way callers are expected to.
+type lockedStack struct {
+	mu sync.Mutex
+	s  Stack[int]
+}
+
+// The model of a stack: its elements with the top at the end.
+type stackModel []int
+
+type lockedPush struct{ val int }
+
+func (w lockedPush) PreCondition(stackModel) bool { return true }
+func (w lockedPush) Run(l *lockedStack) any {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.s = Push(l.s, w.val)
+	return nil
+}
+func (w lockedPush) PostCondition(stackModel, any) error { return nil }
+func (w lockedPush) NextState(m stackModel) stackModel {
+	return append(append(stackModel{}, m...), w.val)
+}
+func (w lockedPush) String() string { return fmt.Sprintf("Push(%v)", w.val) }
+
+// Pops the top of the stack and returns it, or nil if the stack was empty.
+type lockedPop struct{}
+
+func (w lockedPop) PreCondition(stackModel) bool { return true }
+func (w lockedPop) Run(l *lockedStack) any {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	val, ok := Peek(l.s)
+	if !ok {
+		return nil
+	}
+	l.s = Pop(l.s)
+	return val
+}
+func (w lockedPop) PostCondition(m stackModel, result any) error {
+	if len(m) == 0 && result != nil {
+		return fmt.Errorf("expected the stack to be empty")
+	}
+	if len(m) > 0 && result != m[len(m)-1] {
+		return fmt.Errorf("expected %v", m[len(m)-1])
+	}
+	return nil
+}
+func (w lockedPop) NextState(m stackModel) stackModel {
+	if len(m) == 0 {
+		return m
+	}
+	return m[:len(m)-1]
+}
+func (w lockedPop) String() string { return "Pop" }
+
+func TestLockedStackIsLinearizable(t *testing.T) {
+	type command = propcheck.Command[*lockedStack, stackModel]
+	sm := propcheck.StateMachine[*lockedStack, stackModel]{
+		InitialModel: func() stackModel { return nil },
+		NewSystem:    func() *lockedStack { return &lockedStack{s: NewStack[int]()} },
+		Commands: func(stackModel) propcheck.Gen[command] {
+			return propcheck.FlatMap(propcheck.ChooseInt(0, 2), func(i int) propcheck.Gen[command] {
+				if i == 0 {
+					return propcheck.Map(propcheck.ChooseInt(0, 100), func(v int) command { return lockedPush{v} })
+				}
+				return propcheck.Id[command](lockedPop{})
+			})
+		},
+	}
+	//Check prints the seed and A command that replays the command sequences of A failure. The interleaving depends on the scheduler.
+	propcheck.Check(t, sm.ParallelProp("Push and Pop on a locked stack are linearizable", 3), propcheck.WithTestCases(100))
+}